@@ -18,22 +18,56 @@ The branch is created from the current HEAD and added to the stack
 after the current branch. If you're on the base branch, it becomes
 the first branch in the stack.
 
+If branch_prefix is set in .stk.yaml, it is prepended to names that
+don't already contain a '/'.
+
+Use --parent to create the branch from another branch's tip and insert it
+right after that branch, wherever HEAD currently is. The parent must be in
+the stack or be its base.
+
+Use --commit with -m to make an empty first commit on the new branch, so
+'stk submit' can open a PR for it before any real work lands.
+
+Use --description (-d) to note what the branch is for. It's shown next to
+the branch in 'stk status' and 'stk log'; change it with 'stk describe'.
+
 Examples:
   stk branch feature-auth      # Create and add to stack
-  stk branch feature-api       # Create next branch in sequence`,
+  stk branch feature-api       # Create next branch in sequence
+  stk branch feature-c --parent feature-a  # Insert after feature-a
+  stk branch feature-ui --commit -m "WIP: settings page"
+  stk branch feature-db -d "schema migrations"`,
 	Aliases: []string{"br"},
 	Args:    cobra.ExactArgs(1),
 	RunE:    runBranch,
 }
 
+var (
+	branchCommit  bool
+	branchMessage string
+	branchParent  string
+	branchDesc    string
+)
+
 func init() {
+	branchCmd.Flags().StringVar(&branchParent, "parent", "", "create the branch from this branch and insert it after it")
+	branchCmd.Flags().BoolVar(&branchCommit, "commit", false, "make an empty initial commit on the new branch")
+	branchCmd.Flags().StringVarP(&branchMessage, "message", "m", "", "message for the --commit commit")
+	branchCmd.Flags().StringVarP(&branchDesc, "description", "d", "", "note what the branch is for")
 	rootCmd.AddCommand(branchCmd)
 }
 
 func runBranch(cmd *cobra.Command, args []string) error {
-	branchName := args[0]
+	branchName := Config().PrefixBranch(args[0])
 	stack := RequireStack()
 
+	if branchCommit && branchMessage == "" {
+		return fmt.Errorf("--commit requires a message (-m)")
+	}
+	if !branchCommit && branchMessage != "" {
+		return fmt.Errorf("-m is only used with --commit")
+	}
+
 	RequireCleanTree()
 
 	// Check if branch already exists
@@ -47,6 +81,19 @@ func runBranch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not determine current branch: %w", err)
 	}
 
+	// An explicit parent replaces the current branch as starting point
+	if branchParent != "" {
+		if branchParent != stack.Base && !stack.HasBranch(branchParent) {
+			return fmt.Errorf("parent %q is not in the stack or its base", branchParent)
+		}
+		if current != branchParent {
+			if err := Git().Checkout(branchParent); err != nil {
+				return fmt.Errorf("failed to checkout parent: %w", err)
+			}
+			current = branchParent
+		}
+	}
+
 	// Create and checkout the new branch
 	if err := Git().CreateAndCheckout(branchName); err != nil {
 		return fmt.Errorf("failed to create branch: %w", err)
@@ -64,6 +111,12 @@ func runBranch(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if branchDesc != "" {
+		if err := Manager().SetDescription(stack, branchName, branchDesc); err != nil {
+			return err
+		}
+	}
+
 	ui.Success("Created branch %q", branchName)
 	if current == stack.Base {
 		fmt.Printf("  Added as first branch in stack\n")
@@ -71,6 +124,12 @@ func runBranch(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Added after %s\n", current)
 	}
 
+	if branchCommit {
+		if err := Git().CommitEmpty(branchMessage); err != nil {
+			return fmt.Errorf("failed to create initial commit: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -82,6 +141,9 @@ var addCmd = &cobra.Command{
 By default, the branch is added at the end of the stack.
 Use --after to insert it after a specific branch.
 
+A branch can only belong to one stack. Use --force to add it anyway,
+or set allow_shared_branches: true in .stk.yaml to disable the check.
+
 Examples:
   stk add feature-auth                    # Add at end
   stk add feature-api --after feature-auth # Add after specific branch`,
@@ -89,10 +151,14 @@ Examples:
 	RunE: runAdd,
 }
 
-var addAfter string
+var (
+	addAfter string
+	addForce bool
+)
 
 func init() {
 	addCmd.Flags().StringVar(&addAfter, "after", "", "add after this branch")
+	addCmd.Flags().BoolVarP(&addForce, "force", "f", false, "add even if the branch belongs to another stack")
 	rootCmd.AddCommand(addCmd)
 }
 
@@ -110,6 +176,14 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("branch %q is already in the stack", branchName)
 	}
 
+	if addForce {
+		owners, _ := Manager().StacksContaining(branchName)
+		for _, owner := range owners {
+			ui.Warning("Branch %q also belongs to stack %q", branchName, owner)
+		}
+		Manager().AllowSharedBranches = true
+	}
+
 	if addAfter != "" {
 		if err := Manager().AddBranch(stack, branchName, addAfter); err != nil {
 			return err
@@ -159,15 +233,30 @@ var moveCmd = &cobra.Command{
 	Long: `Reorder a branch within the stack.
 
 Use --after to specify the new position.
-Use --after with the base branch name to move to the beginning.`,
+Use --after with the base branch name to move to the beginning.
+
+Use --with-descendants to move the branch together with every branch
+after it (or up to and including --to) as a block, preserving their
+order. The stack is then rebased into its new order.
+
+Examples:
+  stk move feature-c --after feature-a
+  stk move feature-b --after main --with-descendants
+  stk move feature-b --after feature-e --with-descendants --to feature-c`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMove,
 }
 
-var moveAfter string
+var (
+	moveAfter           string
+	moveWithDescendants bool
+	moveTo              string
+)
 
 func init() {
 	moveCmd.Flags().StringVar(&moveAfter, "after", "", "move after this branch (required)")
+	moveCmd.Flags().BoolVar(&moveWithDescendants, "with-descendants", false, "also move the branches after this one")
+	moveCmd.Flags().StringVar(&moveTo, "to", "", "last branch to move with --with-descendants (default: end of stack)")
 	moveCmd.MarkFlagRequired("after")
 	rootCmd.AddCommand(moveCmd)
 }
@@ -176,11 +265,195 @@ func runMove(cmd *cobra.Command, args []string) error {
 	branchName := args[0]
 	stack := RequireStack()
 
-	if err := Manager().MoveBranch(stack, branchName, moveAfter); err != nil {
+	if !moveWithDescendants {
+		if moveTo != "" {
+			return fmt.Errorf("--to requires --with-descendants")
+		}
+		if err := Manager().MoveBranch(stack, branchName, moveAfter); err != nil {
+			return err
+		}
+
+		ui.Success("Moved %q after %q", branchName, moveAfter)
+		return nil
+	}
+
+	RequireCleanTree()
+
+	last := moveTo
+	if last == "" && len(stack.Branches) > 0 {
+		last = stack.Branches[len(stack.Branches)-1].Name
+	}
+
+	// The moved branches' own commits start after their current parents
+	oldParents := make(map[string]string)
+	for _, b := range stack.Branches {
+		oldParents[b.Name] = stack.GetParent(b.Name)
+	}
+
+	if err := Manager().MoveRange(stack, branchName, last, moveAfter); err != nil {
 		return err
 	}
 
-	ui.Success("Moved %q after %q", branchName, moveAfter)
+	if last == branchName {
+		ui.Success("Moved %q after %q", branchName, moveAfter)
+	} else {
+		ui.Success("Moved %q through %q after %q", branchName, last, moveAfter)
+	}
+
+	fmt.Println()
+	return restackBranches(stack, restackOptions{oldParents: oldParents})
+}
+
+var setBranchBaseCmd = &cobra.Command{
+	Use:   "set-branch-base <branch> [base]",
+	Short: "Override the base of a branch in the stack",
+	Long: `Make a branch target a different base than its parent in the stack.
+
+The override is used when rebasing the stack and when choosing the
+base of the branch's PR. Use --clear to go back to the in-stack parent.
+
+Examples:
+  stk set-branch-base hotfix main     # Rebase hotfix onto main, PR targets main
+  stk set-branch-base hotfix --clear  # Back to the previous branch in the stack`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSetBranchBase,
+}
+
+var setBranchBaseClear bool
+
+func init() {
+	setBranchBaseCmd.Flags().BoolVar(&setBranchBaseClear, "clear", false, "remove the base override")
+	rootCmd.AddCommand(setBranchBaseCmd)
+}
+
+func runSetBranchBase(cmd *cobra.Command, args []string) error {
+	branchName := args[0]
+	stack := RequireStack()
+
+	if setBranchBaseClear {
+		if len(args) > 1 {
+			return fmt.Errorf("--clear does not take a base")
+		}
+		if err := Manager().SetBaseOverride(stack, branchName, ""); err != nil {
+			return err
+		}
+		ui.Success("Cleared base override of %q (now %q)", branchName, stack.GetParent(branchName))
+		return nil
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("specify a base branch, or use --clear")
+	}
+	base := args[1]
+
+	if !Git().BranchExists(base) {
+		return fmt.Errorf("branch %q does not exist", base)
+	}
+
+	if err := Manager().SetBaseOverride(stack, branchName, base); err != nil {
+		return err
+	}
+
+	ui.Success("Set base of %q to %q", branchName, base)
+	fmt.Println(ui.Dim + "Run 'stk sync --no-fetch' to rebase onto the new base" + ui.Reset)
+	return nil
+}
+
+var freezeCmd = &cobra.Command{
+	Use:   "freeze <branch>",
+	Short: "Stop stk from rebasing or pushing a branch",
+	Long: `Freeze a branch so stk never rewrites it.
+
+Frozen branches are skipped when the stack is rebased ('stk sync',
+'stk move', 'stk pr merge --restack') and when 'stk submit' pushes, with
+a notice each time. Branches above a frozen branch are still rebased
+onto its current tip. Use this for shared branches, e.g. an integration
+branch others push to. 'stk doctor' warns when a frozen branch falls
+behind its parent.
+
+Examples:
+  stk freeze integration    # Leave integration alone
+  stk unfreeze integration  # Manage it again`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFreeze,
+}
+
+var unfreezeCmd = &cobra.Command{
+	Use:   "unfreeze <branch>",
+	Short: "Let stk rebase and push a frozen branch again",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runFreeze,
+}
+
+func init() {
+	rootCmd.AddCommand(freezeCmd)
+	rootCmd.AddCommand(unfreezeCmd)
+}
+
+var describeCmd = &cobra.Command{
+	Use:   "describe <branch> [text]",
+	Short: "Show or set a branch's description",
+	Long: `Show or set the description of a branch in the stack.
+
+Descriptions are notes for yourself and reviewers of the stack layout; they
+are shown next to the branch in 'stk status' and 'stk log' and never touch
+git. Without text, the current description is printed. Pass "" to clear it.
+
+Examples:
+  stk describe feature-auth "login and session handling"
+  stk describe feature-auth      # Print the description
+  stk describe feature-auth ""   # Clear it`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDescribe,
+}
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+}
+
+func runDescribe(cmd *cobra.Command, args []string) error {
+	branchName := args[0]
+	stack := RequireStack()
+
+	idx := stack.FindBranch(branchName)
+	if idx < 0 {
+		return fmt.Errorf("branch %q not in stack", branchName)
+	}
+
+	if len(args) == 1 {
+		if desc := stack.Branches[idx].Description; desc != "" {
+			fmt.Println(desc)
+		} else {
+			ui.DimText("%s has no description", branchName)
+		}
+		return nil
+	}
+
+	if err := Manager().SetDescription(stack, branchName, args[1]); err != nil {
+		return err
+	}
+	if args[1] == "" {
+		ui.Success("Cleared the description of %q", branchName)
+	} else {
+		ui.Success("Updated the description of %q", branchName)
+	}
+	return nil
+}
+
+func runFreeze(cmd *cobra.Command, args []string) error {
+	branchName := args[0]
+	stack := RequireStack()
+	frozen := cmd.Name() == "freeze"
+
+	if err := Manager().SetFrozen(stack, branchName, frozen); err != nil {
+		return err
+	}
+
+	if frozen {
+		ui.Success("Froze %q; stk won't rebase or push it", branchName)
+	} else {
+		ui.Success("Unfroze %q", branchName)
+	}
 	return nil
 }
 
@@ -243,6 +516,10 @@ func runDown(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not determine current branch: %w", err)
 	}
 
+	if len(stack.Branches) == 0 {
+		return errNoBranches(stack.Name)
+	}
+
 	var child string
 	if current == stack.Base {
 		if len(stack.Branches) > 0 {
@@ -267,6 +544,11 @@ func runDown(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// errNoBranches explains that a stack is still empty and how to fill it.
+func errNoBranches(name string) error {
+	return fmt.Errorf("stack %q has no branches yet; run 'stk branch <name>' to add one", name)
+}
+
 var topCmd = &cobra.Command{
 	Use:   "top",
 	Short: "Checkout the base branch",
@@ -307,7 +589,7 @@ func runBottom(cmd *cobra.Command, args []string) error {
 	RequireCleanTree()
 
 	if len(stack.Branches) == 0 {
-		return fmt.Errorf("stack has no branches")
+		return errNoBranches(stack.Name)
 	}
 
 	last := stack.Branches[len(stack.Branches)-1].Name
@@ -354,6 +636,8 @@ func runGoto(cmd *cobra.Command, args []string) error {
 		target = stack.Base
 	} else if n > 0 && n <= len(stack.Branches) {
 		target = stack.Branches[n-1].Name
+	} else if n > 0 && len(stack.Branches) == 0 {
+		return errNoBranches(stack.Name)
 	} else {
 		return fmt.Errorf("position %d out of range (stack has %d branches)", n, len(stack.Branches))
 	}
@@ -0,0 +1,21 @@
+package cli
+
+import "testing"
+
+func TestMoveWithDescendantsReplaysOwnCommits(t *testing.T) {
+	dir := initRepo(t)
+	commitStack(t, dir, "models", "api", "ui")
+
+	moveAfter, moveWithDescendants = "models", true
+	t.Cleanup(func() { moveAfter, moveWithDescendants = "", false })
+	if err := runMove(moveCmd, []string{"ui"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := subjects(t, "main..ui"); got != "models ui" {
+		t.Errorf("main..ui has %q, want models ui", got)
+	}
+	if got := subjects(t, "main..api"); got != "models ui api" {
+		t.Errorf("main..api has %q, want models ui api", got)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/stefanaki/stk/internal/git"
+	"github.com/stefanaki/stk/internal/stack"
 )
 
 var completionCmd = &cobra.Command{
@@ -62,3 +65,33 @@ PowerShell:
 func init() {
 	rootCmd.AddCommand(completionCmd)
 }
+
+// completionManager constructs a stack manager for dynamic completions,
+// which run without the root pre-run. Returns nil outside a git repository.
+func completionManager() *stack.Manager {
+	gc := git.New()
+	if !gc.IsInsideWorkTree() {
+		return nil
+	}
+	gitDir, err := gc.GitDir()
+	if err != nil {
+		return nil
+	}
+	return stack.NewManager(gitDir)
+}
+
+// completeStackNames completes the first positional argument with stack names.
+func completeStackNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	m := completionManager()
+	if m == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, err := m.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
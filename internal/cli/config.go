@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stefanaki/stk/internal/config"
+	"github.com/stefanaki/stk/internal/ui"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration commands",
+	Long:  `Commands for managing the repository's .stk.yaml.`,
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a commented .stk.yaml",
+	Long: `Write a .stk.yaml at the repository root listing every supported
+setting with its default value and a comment explaining it.
+
+An existing .stk.yaml is left alone unless --force is given, which also
+replaces a file too malformed to load. Only the repository's .stk.yaml is
+supported; stk has no global config file.
+
+Examples:
+  stk config init          # Create .stk.yaml
+  stk config init --force  # Replace an existing .stk.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runConfigInit,
+}
+
+var configInitForce bool
+
+func init() {
+	configInitCmd.Flags().BoolVarP(&configInitForce, "force", "f", false, "overwrite an existing config file")
+	configCmd.AddCommand(configInitCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	root, err := Git().RepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find repository root: %w", err)
+	}
+
+	path := config.Path(root)
+	if _, err := os.Stat(path); err == nil && !configInitForce {
+		return fmt.Errorf("%s already exists; use --force to overwrite it", path)
+	}
+
+	if err := os.WriteFile(path, config.Scaffold(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	ui.Success("Wrote %s", path)
+	return nil
+}
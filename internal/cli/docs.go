@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+var genDocsCmd = &cobra.Command{
+	Use:   "gen-docs --dir <path>",
+	Short: "Generate man pages and markdown docs",
+	Long: `Generate man pages and markdown reference docs for all commands.
+
+Man pages are written to <dir>/man and markdown files to <dir>/markdown.
+This is meant for packagers and the build, not for day-to-day use.
+
+Examples:
+  stk gen-docs --dir docs`,
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runGenDocs,
+}
+
+var genDocsDir string
+
+func init() {
+	genDocsCmd.Flags().StringVar(&genDocsDir, "dir", "", "directory to write the docs to (required)")
+	genDocsCmd.MarkFlagRequired("dir")
+	rootCmd.AddCommand(genDocsCmd)
+}
+
+func runGenDocs(cmd *cobra.Command, args []string) error {
+	manDir := filepath.Join(genDocsDir, "man")
+	mdDir := filepath.Join(genDocsDir, "markdown")
+	for _, dir := range []string{manDir, mdDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	// Keep generated files reproducible across builds
+	rootCmd.DisableAutoGenTag = true
+
+	header := &doc.GenManHeader{
+		Title:   "STK",
+		Section: "1",
+		Source:  "stk " + Version,
+		Manual:  "stk Manual",
+	}
+	if err := doc.GenManTree(rootCmd, header, manDir); err != nil {
+		return fmt.Errorf("failed to generate man pages: %w", err)
+	}
+	if err := doc.GenMarkdownTree(rootCmd, mdDir); err != nil {
+		return fmt.Errorf("failed to generate markdown: %w", err)
+	}
+
+	fmt.Printf("Wrote man pages to %s and markdown to %s\n", manDir, mdDir)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/stefanaki/stk/internal/stack"
 	"github.com/stefanaki/stk/internal/ui"
 )
 
@@ -18,20 +19,60 @@ This allows you to edit, squash, or reorder commits within the current
 
 After editing, run 'stk sync --no-fetch' to propagate changes through the stack.
 
+If the rebase stops for a conflict, resolve it, stage the files and run
+'stk edit --continue'.
+
+Use --all to clean up commits across the whole stack: each branch, from
+the bottom up, is rebased interactively onto its (already edited) parent,
+moving on once the previous rebase finishes. Progress is saved, so after
+a conflict or an 'edit' stop, 'stk edit --continue' picks up where it
+left off. 'stk edit --abort' restores every branch to where it was
+before the edit started. Frozen branches are skipped.
+
 Examples:
   stk edit              # Edit current branch's commits
-  stk edit feature-api  # Edit specific branch's commits`,
+  stk edit feature-api  # Edit specific branch's commits
+  stk edit --all        # Edit every branch in turn
+  stk edit --continue   # Continue after resolving conflicts
+  stk edit --abort      # Undo an unfinished 'stk edit --all'`,
 	RunE: runEdit,
 }
 
+var (
+	editContinue bool
+	editAll      bool
+	editAbort    bool
+)
+
 func init() {
+	editCmd.Flags().BoolVar(&editContinue, "continue", false, "continue an edit after resolving conflicts")
+	editCmd.Flags().BoolVar(&editAll, "all", false, "edit every branch in the stack, one after another")
+	editCmd.Flags().BoolVar(&editAbort, "abort", false, "abort 'stk edit --all' and restore all branches")
+	editCmd.MarkFlagsMutuallyExclusive("continue", "all", "abort")
 	rootCmd.AddCommand(editCmd)
 }
 
 func runEdit(cmd *cobra.Command, args []string) error {
+	if (editContinue || editAll || editAbort) && len(args) > 0 {
+		return fmt.Errorf("--continue, --all and --abort do not take a branch")
+	}
+	if editContinue {
+		return continueEdit()
+	}
+	if editAbort {
+		return abortEditAll()
+	}
+
 	stk := RequireStack()
+	if stk.EditAll != nil {
+		return fmt.Errorf("'stk edit --all' is in progress; run 'stk edit --continue' or 'stk edit --abort'")
+	}
 	RequireCleanTree()
 
+	if editAll {
+		return startEditAll(stk)
+	}
+
 	var branch string
 	if len(args) > 0 {
 		branch = args[0]
@@ -70,3 +111,169 @@ func runEdit(cmd *cobra.Command, args []string) error {
 
 	return Git().RebaseInteractive(parent)
 }
+
+// continueEdit resumes an interactive rebase that stopped for a conflict,
+// and then the rest of an in-progress 'stk edit --all'.
+func continueEdit() error {
+	if stk, err := Manager().Current(); err == nil && stk.EditAll != nil {
+		return continueEditAll(stk)
+	}
+	if !Git().IsRebaseInProgress() {
+		return fmt.Errorf("no rebase in progress")
+	}
+
+	branch := Git().RebaseHeadBranch()
+	if err := Git().RebaseContinue(); err != nil {
+		return fmt.Errorf("failed to continue the rebase; resolve it and run 'stk edit --continue': %w", err)
+	}
+	if Git().IsRebaseInProgress() {
+		fmt.Println()
+		fmt.Println("The rebase stopped again; resolve it and run 'stk edit --continue'.")
+		return nil
+	}
+
+	fmt.Println()
+	if branch != "" {
+		ui.Success("Finished editing %s", branch)
+	} else {
+		ui.Success("Rebase finished")
+	}
+	fmt.Println("Run 'stk sync --no-fetch' to propagate changes through the stack.")
+	return nil
+}
+
+// rebaseInProgressHint describes an in-progress rebase and how to finish
+// it, or returns an empty string if there is none.
+func rebaseInProgressHint() string {
+	if !Git().IsRebaseInProgress() {
+		return ""
+	}
+	if branch := Git().RebaseHeadBranch(); branch != "" {
+		return fmt.Sprintf("a rebase of %s is in progress; resolve it and run 'stk edit --continue', or 'git rebase --abort'", branch)
+	}
+	return "a rebase is in progress; resolve it and run 'stk edit --continue', or 'git rebase --abort'"
+}
+
+// startEditAll snapshots the stack and starts editing it branch by branch.
+func startEditAll(stk *stack.Stack) error {
+	var remaining []string
+	for _, b := range stk.Branches {
+		if b.Frozen {
+			fmt.Printf("%s Skipping %s (frozen)\n", ui.IconInfo, b.Name)
+			continue
+		}
+		remaining = append(remaining, b.Name)
+	}
+	if len(remaining) == 0 {
+		return errNoBranches(stk.Name)
+	}
+
+	fmt.Println(ui.IconCamera + " Saving branch positions for rollback...")
+	if err := Manager().TakeSnapshot(stk, func(name string) (string, error) {
+		return Git().SHA(name)
+	}); err != nil {
+		return fmt.Errorf("failed to take snapshot: %w", err)
+	}
+
+	original, _ := Git().CurrentBranch()
+	if err := Manager().SetEditProgress(stk, &stack.EditProgress{
+		Remaining: remaining,
+		Total:     len(remaining),
+		Original:  original,
+	}); err != nil {
+		return err
+	}
+	return editRemaining(stk)
+}
+
+// continueEditAll finishes the paused rebase, if any, and edits the
+// remaining branches.
+func continueEditAll(stk *stack.Stack) error {
+	if Git().IsRebaseInProgress() {
+		if err := Git().RebaseContinue(); err != nil {
+			return fmt.Errorf("failed to continue the rebase; resolve it and run 'stk edit --continue': %w", err)
+		}
+		if Git().IsRebaseInProgress() {
+			fmt.Println()
+			fmt.Println("The rebase stopped again; resolve it and run 'stk edit --continue'.")
+			return nil
+		}
+	}
+
+	// The branch at the head of the list has been edited
+	progress := *stk.EditAll
+	progress.Remaining = progress.Remaining[1:]
+	if err := Manager().SetEditProgress(stk, &progress); err != nil {
+		return err
+	}
+	return editRemaining(stk)
+}
+
+// editRemaining rebases each remaining branch interactively onto its
+// parent, replaying only the commits it had when the edit started.
+// It returns early, keeping the progress, when a rebase pauses.
+func editRemaining(stk *stack.Stack) error {
+	for len(stk.EditAll.Remaining) > 0 {
+		progress := *stk.EditAll
+		branch := progress.Remaining[0]
+		parent := stk.GetParent(branch)
+		upstream := parent
+		if sha, ok := stk.Snapshot.Refs[parent]; ok {
+			upstream = sha
+		}
+
+		fmt.Printf("%s [%d/%d] Editing %s%s%s onto %s%s%s\n",
+			ui.IconArrow, progress.Total-len(progress.Remaining)+1, progress.Total,
+			ui.Bold, branch, ui.Reset,
+			ui.Dim, parent, ui.Reset)
+
+		if err := Git().Checkout(branch); err != nil {
+			return fmt.Errorf("failed to checkout %s: %w", branch, err)
+		}
+		err := Git().RebaseInteractive(upstream, "--onto", parent)
+		if Git().IsRebaseInProgress() {
+			fmt.Println()
+			fmt.Printf("Editing %s paused; finish it and run 'stk edit --continue', or 'stk edit --abort' to undo the whole edit.\n", branch)
+			return nil
+		}
+		if err != nil {
+			ui.Error("Rebase failed")
+			_ = Manager().SetEditProgress(stk, nil)
+			rollbackStack(stk, progress.Original, nil)
+			return fmt.Errorf("edit of %s failed", branch)
+		}
+
+		progress.Remaining = progress.Remaining[1:]
+		if err := Manager().SetEditProgress(stk, &progress); err != nil {
+			return err
+		}
+	}
+
+	total := stk.EditAll.Total
+	original := stk.EditAll.Original
+	_ = Manager().SetEditProgress(stk, nil)
+	_ = Manager().ClearSnapshot(stk)
+	if original != "" {
+		_ = Git().CheckoutSilent(original)
+	}
+
+	fmt.Println()
+	ui.Success("Edited %d branch(es)", total)
+	fmt.Println("Run 'stk submit' to push the rewritten branches.")
+	return nil
+}
+
+// abortEditAll abandons 'stk edit --all' and restores the snapshot.
+func abortEditAll() error {
+	stk := RequireStack()
+	if stk.EditAll == nil {
+		return fmt.Errorf("no 'stk edit --all' in progress")
+	}
+
+	original := stk.EditAll.Original
+	if err := Manager().SetEditProgress(stk, nil); err != nil {
+		return err
+	}
+	rollbackStack(stk, original, nil)
+	return nil
+}
@@ -14,21 +14,44 @@ var initCmd = &cobra.Command{
 	Long: `Initialize a new stack with the given name.
 
 The current branch will be used as the starting point. If --base is not
-specified, the tool will try to detect the default branch (main/master)
-or use the upstream branch.
+specified, the tool will try to detect the default branch (origin/HEAD,
+then main/master, then the repository's default branch as reported by
+GitHub/GitLab) or use the upstream branch.
+
+Use --on-stack to build a follow-up stack on top of another stack: the
+new stack's base becomes that stack's last branch, so syncing the new
+stack rebuilds it on top of the other.
+
+If the current branch already belongs to another stack, init refuses
+to continue unless --force is given or allow_shared_branches is set in
+.stk.yaml.
+
+Use --empty to create the stack without adding the current branch, e.g.
+when you're on a throwaway branch. Check out the base and use
+'stk branch' to add the first branch.
 
 Examples:
-  stk init my-feature              # Create stack, auto-detect base
-  stk init my-feature --base main  # Create stack with explicit base
-  stk init my-feature -b develop   # Use develop as base`,
+  stk init my-feature                      # Create stack, auto-detect base
+  stk init my-feature --base main          # Create stack with explicit base
+  stk init my-feature -b develop           # Use develop as base
+  stk init part-two --on-stack my-feature  # Stack on top of my-feature
+  stk init my-feature --empty              # Don't add the current branch`,
 	Args: cobra.ExactArgs(1),
 	RunE: runInit,
 }
 
-var initBase string
+var (
+	initBase    string
+	initForce   bool
+	initOnStack string
+	initEmpty   bool
+)
 
 func init() {
 	initCmd.Flags().StringVarP(&initBase, "base", "b", "", "base branch for the stack")
+	initCmd.Flags().StringVar(&initOnStack, "on-stack", "", "use the last branch of this stack as the base")
+	initCmd.Flags().BoolVar(&initEmpty, "empty", false, "don't add the current branch to the stack")
+	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "create the stack even if the current branch is in another stack")
 	rootCmd.AddCommand(initCmd)
 }
 
@@ -40,12 +63,34 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("stack %q already exists", stackName)
 	}
 
+	if initOnStack != "" && initBase != "" {
+		return fmt.Errorf("--on-stack and --base cannot be used together")
+	}
+
 	// Determine base branch
 	base := initBase
+	if initOnStack != "" {
+		parent, err := Manager().Load(initOnStack)
+		if err != nil {
+			return err
+		}
+		if len(parent.Branches) == 0 {
+			return fmt.Errorf("stack %q has no branches to build on", initOnStack)
+		}
+		base = parent.Branches[len(parent.Branches)-1].Name
+		if !Git().BranchExists(base) {
+			return fmt.Errorf("tip branch %q of stack %q does not exist", base, initOnStack)
+		}
+	}
 	if base == "" {
 		// Try to auto-detect
 		var err error
 		base, err = Git().DefaultBranch()
+		if err != nil {
+			// Fresh clones (e.g. CI checkouts) may lack origin/HEAD;
+			// ask the provider
+			base, err = providerDefaultBranch()
+		}
 		if err != nil {
 			// Try upstream
 			base, err = Git().UpstreamBranch()
@@ -66,6 +111,21 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not determine current branch (detached HEAD?)")
 	}
 
+	// Add the current branch unless it is the base or --empty was given
+	addCurrent := current != base && !initEmpty
+
+	// Refuse to put the current branch in a second stack by accident
+	if addCurrent {
+		owners, _ := Manager().StacksContaining(current)
+		if len(owners) > 0 {
+			if !initForce && !Manager().AllowSharedBranches {
+				return fmt.Errorf("branch %q is already in stack %q; use --force to create an overlapping stack", current, owners[0])
+			}
+			ui.Warning("Branch %q is already in stack %q", current, owners[0])
+			Manager().AllowSharedBranches = true
+		}
+	}
+
 	// Create the stack
 	stack, err := Manager().Create(stackName, base)
 	if err != nil {
@@ -73,7 +133,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// If current branch is not the base, add it to the stack
-	if current != base {
+	if addCurrent {
 		if err := Manager().AppendBranch(stack, current); err != nil {
 			return err
 		}
@@ -86,14 +146,31 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	ui.Success("Initialized stack %q", stackName)
 	fmt.Println()
-	fmt.Printf("  Base: %s\n", base)
-	if current != base {
+	if initOnStack != "" {
+		fmt.Printf("  Base: %s (tip of stack %q)\n", base, initOnStack)
+	} else {
+		fmt.Printf("  Base: %s\n", base)
+	}
+	if addCurrent {
 		fmt.Printf("  Branch: %s\n", current)
 	}
 	fmt.Println()
 	fmt.Println("Next steps:")
+	if current != base && !addCurrent {
+		fmt.Printf("  git checkout %s  Start the first branch from the base\n", base)
+	}
 	fmt.Println("  stk branch <name>  Create a new branch in the stack")
 	fmt.Println("  stk status         Show stack status")
 
 	return nil
 }
+
+// providerDefaultBranch asks the PR provider for the repository's default
+// branch.
+func providerDefaultBranch() (string, error) {
+	provider, err := getProvider()
+	if err != nil {
+		return "", err
+	}
+	return provider.DefaultBranch()
+}
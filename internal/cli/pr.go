@@ -1,13 +1,20 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/stefanaki/stk/internal/config"
 	"github.com/stefanaki/stk/internal/pr"
 	"github.com/stefanaki/stk/internal/stack"
 	"github.com/stefanaki/stk/internal/ui"
@@ -23,14 +30,36 @@ func init() {
 	rootCmd.AddCommand(prCmd)
 }
 
-// getProvider returns the configured PR provider for the current repo.
-func getProvider() (pr.Provider, error) {
+// prTarget describes the repository PRs are opened on.
+type prTarget struct {
+	URL       string // remote URL of the repository holding the PRs
+	Remote    string // name of the remote with that URL
+	HeadOwner string // owner of the fork branches are pushed to, if any
+}
+
+// resolvePRTarget works out where PRs go. Branches are always pushed to
+// origin; when origin is a fork of the "upstream" remote, PRs live on the
+// upstream repository. headOwner, if set, overrides the detected fork owner.
+func resolvePRTarget(headOwner string) (prTarget, error) {
 	remoteURL, err := Git().Remote("origin")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get remote URL: %w", err)
+		return prTarget{}, fmt.Errorf("failed to get remote URL: %w", err)
+	}
+
+	t := prTarget{URL: remoteURL, Remote: "origin"}
+	if prURL, owner := forkTarget(remoteURL); prURL != remoteURL {
+		t = prTarget{URL: prURL, Remote: upstreamRemote, HeadOwner: owner}
+	}
+	if headOwner != "" {
+		t.HeadOwner = headOwner
 	}
+	return t, nil
+}
 
-	provider, err := pr.DetectProvider(remoteURL)
+// providerFor returns the PR provider for a target, set up with its
+// repository and fork owner.
+func providerFor(t prTarget) (pr.Provider, error) {
+	provider, err := pr.DetectProvider(t.URL)
 	if err != nil {
 		return nil, err
 	}
@@ -38,18 +67,32 @@ func getProvider() (pr.Provider, error) {
 	// Set up provider with repo info
 	switch p := provider.(type) {
 	case *pr.GitHubProvider:
-		if err := p.SetRepo(remoteURL); err != nil {
+		if err := p.SetRepo(t.URL); err != nil {
 			return nil, err
 		}
+		p.Token = Git().ConfigString(config.TokenGitConfig)
+		p.HeadOwner = t.HeadOwner
 	case *pr.GitLabProvider:
-		if err := p.SetRepo(remoteURL); err != nil {
+		if err := p.SetRepo(t.URL); err != nil {
 			return nil, err
 		}
+		p.Token = Git().ConfigString(config.TokenGitConfig)
+		p.HeadOwner = t.HeadOwner
 	}
 
 	return provider, nil
 }
 
+// getProvider returns the configured PR provider for the current repo,
+// pointed at the upstream repository when origin is a fork.
+func getProvider() (pr.Provider, error) {
+	t, err := resolvePRTarget("")
+	if err != nil {
+		return nil, err
+	}
+	return providerFor(t)
+}
+
 // collectBranchInfos gathers PR info for all branches in the stack.
 func collectBranchInfos(stk *stack.Stack, provider pr.Provider, refresh bool) []pr.PRBranchInfo {
 	var branchInfos []pr.PRBranchInfo
@@ -63,12 +106,15 @@ func collectBranchInfos(stk *stack.Stack, provider pr.Provider, refresh bool) []
 				remotePR, err := provider.Get(b.PR.Number)
 				if err == nil && remotePR != nil {
 					info.PR = remotePR
+					info.Fetched = true
 					// Update local cache
 					_ = Manager().UpdatePR(stk, b.Name, &stack.PR{
 						Number: remotePR.Number,
 						URL:    remotePR.URL,
 						State:  remotePR.State,
 						Title:  remotePR.Title,
+						Base:   remotePR.Base,
+						Author: remotePR.Author,
 					})
 				} else {
 					info.PR = &pr.PR{
@@ -88,17 +134,166 @@ func collectBranchInfos(stk *stack.Stack, provider pr.Provider, refresh bool) []
 	return branchInfos
 }
 
+// prCache reuses PR state fetched from the provider within the configured
+// TTL, so repeated refreshes don't hit the API every time.
+type prCache struct {
+	entries map[int]stack.CachedPR
+	ttl     time.Duration
+	force   bool // always fetch, but still record the results
+
+	// Changes made in this run, applied on top of the file when saving
+	fetched   map[int]bool
+	forgotten map[int]bool
+}
+
+// newPRCache loads the PR cache. With force set, cached entries are ignored.
+func newPRCache(force bool) *prCache {
+	return &prCache{
+		entries: Manager().Storage().LoadPRCache(),
+		ttl:     Config().PRCacheDuration(),
+		force:   force,
+
+		fetched:   make(map[int]bool),
+		forgotten: make(map[int]bool),
+	}
+}
+
+// get returns a PR from the cache if it's fresh, or from the provider.
+func (c *prCache) get(provider pr.Provider, number int) (*pr.PR, error) {
+	if c.cached(number) {
+		e := c.entries[number]
+		return &pr.PR{
+			Number: e.PR.Number,
+			URL:    e.PR.URL,
+			State:  e.PR.State,
+			Title:  e.PR.Title,
+			Base:   e.PR.Base,
+			Author: e.PR.Author,
+		}, nil
+	}
+
+	remotePR, err := provider.Get(number)
+	if err != nil || remotePR == nil {
+		return remotePR, err
+	}
+	c.entries[number] = stack.CachedPR{
+		PR: stack.PR{
+			Number: remotePR.Number,
+			URL:    remotePR.URL,
+			State:  remotePR.State,
+			Title:  remotePR.Title,
+			Base:   remotePR.Base,
+			Author: remotePR.Author,
+		},
+		FetchedAt: time.Now(),
+	}
+	c.fetched[number] = true
+	delete(c.forgotten, number)
+	return remotePR, nil
+}
+
+// cached reports whether get would answer for a PR from the cache.
+func (c *prCache) cached(number int) bool {
+	e, ok := c.entries[number]
+	return ok && !c.force && time.Since(e.FetchedAt) < c.ttl
+}
+
+// forget drops the cached entry for a PR whose state was just changed.
+func (c *prCache) forget(number int) {
+	delete(c.entries, number)
+	delete(c.fetched, number)
+	c.forgotten[number] = true
+}
+
+// save writes back the entries fetched and forgotten since the cache was
+// loaded, on top of the file's current contents so that other caches
+// saved in the meantime aren't undone.
+func (c *prCache) save() {
+	if len(c.fetched) == 0 && len(c.forgotten) == 0 {
+		return
+	}
+	entries := Manager().Storage().LoadPRCache()
+	for number := range c.fetched {
+		entries[number] = c.entries[number]
+	}
+	for number := range c.forgotten {
+		delete(entries, number)
+	}
+	_ = Manager().Storage().SavePRCache(entries)
+}
+
+// forgetCachedPR drops a PR from the on-disk cache after its state was
+// changed through the provider, so the next refresh fetches it again.
+func forgetCachedPR(number int) {
+	c := newPRCache(false)
+	c.forget(number)
+	c.save()
+}
+
+// stackSection renders the stack section of a PR body, using the
+// stack_section_template from the config when one is set.
+func stackSection(stk *stack.Stack, branchInfos []pr.PRBranchInfo, current string) string {
+	if Config() != nil && Config().StackSectionTemplate != "" {
+		body, err := pr.RenderStackSection(Config().StackSectionTemplate, stk.Name, branchInfos, current)
+		if err == nil {
+			return body
+		}
+		ui.Warning("%v; using default layout", err)
+	}
+	return pr.GenerateStackSection(stk.Name, branchInfos, current)
+}
+
+// stackSectionEnabled reports whether PR descriptions of a stack get a stack
+// section, per the stack's setting and the config.
+func stackSectionEnabled(stk *stack.Stack) bool {
+	return !stk.NoStackSection && Config().StackSectionEnabled()
+}
+
+// newPRBody returns the body of a new PR: the user's text followed by the
+// stack section, unless the section is disabled for the stack.
+func newPRBody(stk *stack.Stack, text string, branchInfos []pr.PRBranchInfo, current string) string {
+	if !stackSectionEnabled(stk) {
+		return text
+	}
+	return pr.ComposeBody(text, stackSection(stk, branchInfos, current))
+}
+
+// applyNoStackSection records an explicit --no-stack-section on the stack so
+// later commands keep honoring it.
+func applyNoStackSection(cmd *cobra.Command, stk *stack.Stack, disabled bool) error {
+	if !cmd.Flags().Changed("no-stack-section") || stk.NoStackSection == disabled {
+		return nil
+	}
+	return Manager().SetNoStackSection(stk, disabled)
+}
+
 // UpdateAllPRDescriptions updates the description of all PRs in the stack with current stack info.
 func UpdateAllPRDescriptions(stk *stack.Stack, provider pr.Provider) error {
+	if !stackSectionEnabled(stk) {
+		return nil
+	}
+
 	branchInfos := collectBranchInfos(stk, provider, true)
 
-	for _, branch := range stk.Branches {
+	for i, branch := range stk.Branches {
 		if branch.PR == nil || branch.PR.Number == 0 {
 			continue
 		}
 
-		// Generate new body with updated stack section
-		body := pr.GenerateStackSection(stk.Name, branchInfos, branch.Name)
+		// Replace the stack section, keeping the text above it. The body
+		// read by the refresh above is reused rather than fetched again.
+		section := stackSection(stk, branchInfos, branch.Name)
+		var body string
+		if info := branchInfos[i]; info.Fetched {
+			body = pr.ReplaceStackSection(info.PR.Body, section)
+		} else {
+			var err error
+			body, err = updatedBody(provider, branch.PR.Number, section)
+			if err != nil {
+				ui.Warning("Skipping PR #%d: %v", branch.PR.Number, err)
+				continue
+			}
+		}
 
 		fmt.Printf("  Updating PR #%d (%s)...\n", branch.PR.Number, branch.Name)
 		if err := provider.Update(branch.PR.Number, pr.UpdateOptions{Body: &body}); err != nil {
@@ -109,6 +304,425 @@ func UpdateAllPRDescriptions(stk *stack.Stack, provider pr.Provider) error {
 	return nil
 }
 
+// updatedBody returns a PR's body with its stack section replaced by
+// section. Text the user wrote above the section is kept. It fails if the
+// current body can't be read, since writing the section alone would wipe
+// the description.
+func updatedBody(provider pr.Provider, number int, section string) (string, error) {
+	remotePR, err := provider.Get(number)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the description of PR #%d: %w", number, err)
+	}
+	if remotePR == nil {
+		return "", fmt.Errorf("failed to read the description of PR #%d", number)
+	}
+	return pr.ReplaceStackSection(remotePR.Body, section), nil
+}
+
+// readBodyFile reads a PR body from a file, or from stdin when path is "-".
+func readBodyFile(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read body file: %w", err)
+	}
+	return string(data), nil
+}
+
+// retargetStackPRs recomputes each PR's intended base from the stack order and
+// retargets any open PR whose base on the remote no longer matches it. This
+// happens after a lower PR is merged and its child is rebased onto the base.
+// Only branches selected by only are considered (nil selects all).
+// Authentication failures abort the loop and are returned.
+func retargetStackPRs(stk *stack.Stack, provider pr.Provider, only branchFilter) error {
+	for _, branch := range stk.Branches {
+		if branch.PR == nil || branch.PR.Number == 0 || !only.has(branch.Name) {
+			continue
+		}
+		if branch.PR.State == "merged" || branch.PR.State == "closed" {
+			continue
+		}
+
+		remotePR, err := provider.Get(branch.PR.Number)
+		if err := authFailure(provider, err); err != nil {
+			return err
+		}
+		if err != nil || remotePR == nil {
+			continue
+		}
+		if remotePR.State == "merged" || remotePR.State == "closed" {
+			continue
+		}
+
+		base := stk.GetPRBase(branch.Name)
+		if remotePR.Base == "" || remotePR.Base == base {
+			recordPRBase(stk, branch.Name, remotePR.Base)
+			continue
+		}
+
+		fmt.Printf("  Retargeting PR #%d (%s): %s → %s\n", branch.PR.Number, branch.Name, remotePR.Base, base)
+		if err := provider.Retarget(branch.PR.Number, base); err != nil {
+			if authErr := authFailure(provider, err); authErr != nil {
+				return authErr
+			}
+			ui.Warning("Failed to retarget PR #%d: %v", branch.PR.Number, err)
+			recordPRBase(stk, branch.Name, remotePR.Base)
+			continue
+		}
+		recordPRBase(stk, branch.Name, base)
+	}
+	return nil
+}
+
+// recordPRBase caches the base a branch's PR targets, for offline checks.
+func recordPRBase(stk *stack.Stack, branch, base string) {
+	idx := stk.FindBranch(branch)
+	if idx < 0 || stk.Branches[idx].PR == nil || base == "" || stk.Branches[idx].PR.Base == base {
+		return
+	}
+	updated := *stk.Branches[idx].PR
+	updated.Base = base
+	_ = Manager().UpdatePR(stk, branch, &updated)
+}
+
+// authFailure returns an actionable error if err is an authentication
+// failure, so callers can abort instead of failing once per branch.
+// Returns nil for any other error.
+func authFailure(provider pr.Provider, err error) error {
+	if !errors.Is(err, pr.ErrUnauthorized) {
+		return nil
+	}
+	return fmt.Errorf("%s authentication failed (check your token and its permissions): %w", provider.Name(), err)
+}
+
+// defaultMaxTitleLength is the default limit for PR titles; GitHub and GitLab
+// both reject or truncate titles beyond roughly this length.
+const defaultMaxTitleLength = 256
+
+// prTitle determines the title for a new PR: the explicit title if given,
+// otherwise, with fill set, the subject of the branch's first commit on top
+// of base, falling back to the branch name. Derived titles longer than
+// maxLen runes are truncated with an ellipsis and a warning is printed; an
+// explicit title is used as given.
+func prTitle(explicit, branch, base string, fill bool, maxLen int) string {
+	if explicit != "" {
+		return explicit
+	}
+
+	title := branch
+	if fill {
+		if subject, err := Git().FirstCommitSubject(base, branch); err == nil && subject != "" {
+			title = subject
+		}
+	}
+
+	if maxLen <= 0 || len([]rune(title)) <= maxLen {
+		return title
+	}
+	ui.Warning("Title for %s truncated to %d characters", branch, maxLen)
+	return ui.Truncate(title, maxLen)
+}
+
+// checkMilestone resolves a milestone title to its ID once for all the PRs
+// of a run. If it can't, it warns and returns 0 so PRs are created without
+// a milestone.
+func checkMilestone(provider pr.Provider, milestone string) int {
+	if milestone == "" {
+		return 0
+	}
+	id, err := provider.FindMilestone(milestone)
+	if err != nil {
+		if errors.Is(err, pr.ErrNotFound) {
+			ui.Warning("Milestone %q does not exist; PRs will be created without a milestone", milestone)
+		} else {
+			ui.Warning("Could not look up milestone %q: %v", milestone, err)
+		}
+		return 0
+	}
+	return id
+}
+
+// parentPRLabels returns the labels of the PR for a branch's parent in the
+// stack, or nil if the parent has no PR or its labels can't be fetched.
+func parentPRLabels(stk *stack.Stack, provider pr.Provider, branch string) []string {
+	idx := stk.FindBranch(stk.GetParent(branch))
+	if idx < 0 {
+		return nil // Parent is the base branch
+	}
+	parent := stk.Branches[idx]
+	if parent.PR == nil || parent.PR.Number == 0 {
+		return nil
+	}
+
+	labels, err := provider.GetLabels(parent.PR.Number)
+	if err != nil {
+		ui.Warning("Failed to get labels of PR #%d: %v", parent.PR.Number, err)
+		return nil
+	}
+	return labels
+}
+
+// mergeLabels returns a followed by the labels of b not already in a.
+func mergeLabels(a, b []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, l := range append(append([]string{}, a...), b...) {
+		if !seen[l] {
+			seen[l] = true
+			result = append(result, l)
+		}
+	}
+	return result
+}
+
+// loadCodeOwners reads the repository's CODEOWNERS file, warning and
+// returning nil if there is none. It also returns the current user, who
+// can't be asked to review their own PRs.
+func loadCodeOwners(provider pr.Provider) (*pr.CodeOwners, string) {
+	root, err := Git().RepoRoot()
+	if err != nil {
+		ui.Warning("Failed to find repository root; --codeowners will be ignored: %v", err)
+		return nil, ""
+	}
+	co, path, err := pr.LoadCodeOwners(root)
+	if errors.Is(err, os.ErrNotExist) {
+		ui.Warning("No CODEOWNERS file found; --codeowners will be ignored")
+		return nil, ""
+	}
+	if err != nil {
+		ui.Warning("Failed to read %s; --codeowners will be ignored: %v", path, err)
+		return nil, ""
+	}
+	if !provider.SupportsReviewers() {
+		ui.Warning("%s doesn't support review requests; --codeowners will be ignored", provider.Name())
+		return nil, ""
+	}
+	self, _ := provider.CurrentUser()
+	return co, self
+}
+
+// branchCodeOwners returns the owners of the files a branch changes
+// compared to its parent, except self.
+func branchCodeOwners(stk *stack.Stack, co *pr.CodeOwners, branch, self string) []string {
+	files, err := Git().DiffNameOnly(stk.GetParent(branch), branch)
+	if err != nil {
+		ui.Warning("Failed to list files changed on %s: %v", branch, err)
+		return nil
+	}
+
+	var owners []string
+	for _, owner := range co.Owners(files) {
+		if !strings.EqualFold(owner, self) {
+			owners = append(owners, owner)
+		}
+	}
+	if len(owners) > 0 {
+		fmt.Printf("  Code owners of %s: %s\n", branch, strings.Join(owners, ", "))
+	}
+	return owners
+}
+
+// Retry schedule for PR creation when the provider hasn't seen a freshly
+// pushed branch yet.
+const (
+	createPRRetries    = 3
+	createPRRetryDelay = 2 * time.Second
+)
+
+// createPR creates a PR, retrying with backoff while the provider reports
+// that the head branch doesn't exist yet.
+func createPR(provider pr.Provider, opts pr.CreateOptions) (*pr.PR, error) {
+	delay := createPRRetryDelay
+	for attempt := 0; ; attempt++ {
+		newPR, err := provider.Create(opts)
+		if !errors.Is(err, pr.ErrHeadNotFound) || attempt == createPRRetries {
+			return newPR, err
+		}
+		fmt.Printf("  %s hasn't reached %s yet; retrying in %s...\n", opts.Head, provider.Name(), delay)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// recoverPRs looks up the remote PR of each branch without PR metadata
+// (or only the named branch) and records it in the stack.
+func recoverPRs(stk *stack.Stack, provider pr.Provider, args []string) error {
+	branches := stk.Branches
+	if len(args) > 0 {
+		idx := stk.FindBranch(args[0])
+		if idx < 0 {
+			return fmt.Errorf("branch %q not in stack", args[0])
+		}
+		branches = []stack.Branch{stk.Branches[idx]}
+	}
+
+	recovered, missing := 0, 0
+	for _, branch := range branches {
+		if branch.PR != nil && branch.PR.Number > 0 {
+			continue
+		}
+
+		remotePR, err := provider.GetByBranch(branch.Name)
+		if err := authFailure(provider, err); err != nil {
+			return err
+		}
+		if err != nil || remotePR == nil {
+			fmt.Printf("  No PR found for %s\n", branch.Name)
+			missing++
+			continue
+		}
+
+		if err := Manager().UpdatePR(stk, branch.Name, &stack.PR{
+			Number: remotePR.Number,
+			URL:    remotePR.URL,
+			State:  remotePR.State,
+			Title:  remotePR.Title,
+			Base:   remotePR.Base,
+			Author: remotePR.Author,
+		}); err != nil {
+			return err
+		}
+		fmt.Printf("  Recovered PR #%d for %s\n", remotePR.Number, branch.Name)
+		recovered++
+	}
+
+	fmt.Println()
+	ui.Success("Recovered %d PR(s), %d branch(es) without a PR", recovered, missing)
+	if missing > 0 {
+		fmt.Println("Run 'stk pr create' to create the missing PRs.")
+	}
+	return nil
+}
+
+// pendingPR is a PR queued for concurrent creation.
+type pendingPR struct {
+	branch string
+	opts   pr.CreateOptions
+}
+
+// createdPR is the outcome of creating a pendingPR.
+type createdPR struct {
+	pr  *pr.PR
+	err error
+}
+
+// createPRsConcurrently creates the pending PRs, up to jobs at a time, and
+// returns the results in the same order. Their stack sections can't list
+// each other's numbers, so callers update descriptions afterwards.
+func createPRsConcurrently(provider pr.Provider, pending []pendingPR, jobs int) []createdPR {
+	results := make([]createdPR, len(pending))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i, p := range pending {
+		wg.Add(1)
+		go func(i int, opts pr.CreateOptions) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			newPR, err := createPR(provider, opts)
+			results[i] = createdPR{pr: newPR, err: err}
+		}(i, p.opts)
+	}
+	wg.Wait()
+	return results
+}
+
+// skipEmptyBranch reports and returns true if branch has no commits over
+// the base its PR would target; providers reject such PRs with an opaque
+// validation error.
+func skipEmptyBranch(branch, base string) bool {
+	count, err := Git().CommitCount(base, branch)
+	if err != nil || count > 0 {
+		return false
+	}
+	fmt.Printf("%s Skipping %s - branch has no commits over its parent %s; nothing to create a PR for\n",
+		ui.IconInfo, branch, base)
+	return true
+}
+
+// requireRemoteBase returns an error if base is the stack's base branch and
+// remote doesn't have it; providers reject PRs against a branch they don't
+// know with an opaque validation error.
+func requireRemoteBase(stk *stack.Stack, base, remote string) error {
+	if base != stk.Base || Git().RemoteBranchExists(remote, base) {
+		return nil
+	}
+	return fmt.Errorf("base branch %s doesn't exist on %s; push it first (git push -u %s %s)", base, remote, remote, base)
+}
+
+// reportExistingPR explains what to do when creating a PR fails because one
+// already exists for the branch but isn't open.
+func reportExistingPR(branch string) {
+	ui.Warning("A PR for %s already exists but is not open", branch)
+	fmt.Printf("  Reopen it on the remote, then run 'stk pr create %s' to track it\n", branch)
+}
+
+// skipTrackedPR decides whether a branch that already has a PR recorded
+// should be skipped when creating PRs, refreshing the PR's state first.
+// Open PRs (reported only if reportOpen is set) and merged PRs are skipped.
+// A closed PR is reopened when reopen is set; otherwise its record is
+// dropped so a fresh PR gets created. Only authentication failures are
+// returned as errors.
+func skipTrackedPR(stk *stack.Stack, provider pr.Provider, cache *prCache, branch stack.Branch, reopen, reportOpen bool) (bool, error) {
+	if branch.PR == nil || branch.PR.Number == 0 {
+		return false, nil
+	}
+
+	number := branch.PR.Number
+	state := branch.PR.State
+	remotePR, err := cache.get(provider, number)
+	if err := authFailure(provider, err); err != nil {
+		return true, err
+	}
+	if err == nil && remotePR != nil && remotePR.State != state {
+		state = remotePR.State
+		updated := *branch.PR
+		updated.State = state
+		_ = Manager().UpdatePR(stk, branch.Name, &updated)
+	}
+
+	switch state {
+	case "merged":
+		fmt.Printf("%s Skipping %s - PR #%d was already merged\n", ui.IconInfo, branch.Name, number)
+		return true, nil
+	case "closed":
+		if !reopen {
+			fmt.Printf("%s PR #%d for %s was closed; creating a new one (use --reopen to reopen it instead)\n",
+				ui.IconInfo, number, branch.Name)
+			_ = Manager().UpdatePR(stk, branch.Name, nil)
+			return false, nil
+		}
+		open := "open"
+		if err := provider.Update(number, pr.UpdateOptions{State: &open}); err != nil {
+			if authErr := authFailure(provider, err); authErr != nil {
+				return true, authErr
+			}
+			ui.Warning("Failed to reopen PR #%d for %s: %v", number, branch.Name, err)
+			return true, nil
+		}
+		cache.forget(number)
+		reopened := *branch.PR
+		reopened.State = "open"
+		_ = Manager().UpdatePR(stk, branch.Name, &reopened)
+		ui.Success("Reopened PR #%d for %s", number, branch.Name)
+		return true, nil
+	default:
+		if reportOpen {
+			fmt.Printf("%s Skipping %s - PR #%d already exists\n", ui.IconInfo, branch.Name, number)
+		}
+		return true, nil
+	}
+}
+
 var prCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create PRs for the stack",
@@ -120,53 +734,175 @@ Each branch gets a PR targeting its parent branch:
 
 The PR description includes a "Stack" section showing all related PRs.
 
+Existing PRs whose base no longer matches their parent in the stack
+(e.g. after a lower PR was merged) are retargeted first. Use
+--target-stack-base=false to leave them untouched.
+
+Use --body-file to supply the PR description from a file ("-" reads
+stdin). The stack section is appended below it, and later updates only
+replace the stack section.
+
+Use --no-stack-section to leave the stack section out entirely. The
+setting is saved on the stack, so 'stk submit' and 'stk pr update' keep
+skipping it; pass --no-stack-section=false to turn the section back on.
+Set stack_section: false in .stk.yaml to disable it for every stack.
+
+Use --closes to add closing keywords (e.g. "Closes #123") for issues
+that should close once the whole stack lands. They go on the bottom
+branch's PR, since it merges last; use --closes-on to pick another branch.
+
+When an "upstream" remote points at a different owner than origin, origin
+is treated as a fork: branches are pushed there and PRs are opened on the
+upstream repository. Use --head-owner to set the fork owner explicitly.
+
+Use --jobs (-j) to create several PRs at once. New PRs then can't list
+each other in their stack sections, so all descriptions are updated in
+one pass afterwards.
+
+Reviewers given with --reviewer are combined with the default reviewers
+from STK_REVIEWERS (comma-separated) or, if that's unset, reviewers in
+.stk.yaml; each is asked once. "@me" stands for you and "team:<slug>" for
+a GitHub team.
+
+Use --codeowners to request reviews from the CODEOWNERS of the files each
+branch changes compared to its parent. Teams and email owners are skipped,
+and so are you.
+
+Branches whose PR was merged are skipped. When a branch's PR was closed,
+a new PR is created in its place; use --reopen to reopen the closed PR
+instead.
+
+PRs are titled after their branch unless --title is given; use --fill to
+use the subject of each branch's first commit instead. Titles derived
+this way are cut to --max-title-length (default 256) characters; an
+explicit --title is used as given.
+
+Use --recover to rebuild lost PR metadata (e.g. after restoring an older
+stack file): existing PRs are looked up by branch and recorded in the
+stack, and nothing is created.
+
 Examples:
   stk pr create              # Create PRs for all branches
   stk pr create --draft      # Create as drafts
-  stk pr create feature-api  # Create PR for specific branch only`,
+  stk pr create feature-api  # Create PR for specific branch only
+  stk pr create --closes 123 # Close issue #123 when the stack merges
+  stk pr create --assign-self
+  stk pr create --fill       # Title PRs after their first commit
+  stk pr create --codeowners # Ask code owners of the changed files to review
+  stk pr create --open       # Open the new PRs in the browser
+  stk pr create --recover    # Re-link existing PRs to the stack
+  stk pr create --reopen     # Reopen closed PRs rather than replacing them
+  stk pr create --body-file notes.md`,
 	RunE: runPRCreate,
 }
 
 var (
-	prCreateDraft     bool
-	prCreateReviewers []string
-	prCreateTitle     string
+	prCreateDraft           bool
+	prCreateReviewers       []string
+	prCreateTitle           string
+	prCreateFill            bool
+	prCreateTargetStackBase bool
+	prCreateMaxTitleLength  int
+	prCreateMilestone       string
+	prCreateLabels          []string
+	prCreateInheritLabels   bool
+	prCreateCloses          []int
+	prCreateClosesOn        string
+	prCreateBodyFile        string
+	prCreateHeadOwner       string
+	prCreateAssignSelf      bool
+	prCreateNoStackSection  bool
+	prCreateOpen            bool
+	prCreateRecover         bool
+	prCreateJobs            int
+	prCreateCodeOwners      bool
+	prCreateReopen          bool
 )
 
 func init() {
 	prCreateCmd.Flags().BoolVar(&prCreateDraft, "draft", false, "create PRs as drafts")
 	prCreateCmd.Flags().StringSliceVar(&prCreateReviewers, "reviewer", nil, "add reviewers")
+	prCreateCmd.Flags().BoolVar(&prCreateCodeOwners, "codeowners", false, "add the CODEOWNERS of changed files as reviewers")
 	prCreateCmd.Flags().StringVarP(&prCreateTitle, "title", "t", "", "PR title (uses branch name if not specified)")
+	prCreateCmd.Flags().BoolVar(&prCreateFill, "fill", false, "title PRs with the subject of the branch's first commit")
+	prCreateCmd.Flags().StringVar(&prCreateBodyFile, "body-file", "", "read the PR body from a file (\"-\" for stdin)")
+	prCreateCmd.Flags().StringSliceVar(&prCreateLabels, "label", nil, "add labels to created PRs")
+	prCreateCmd.Flags().IntVarP(&prCreateJobs, "jobs", "j", 1, "number of PRs to create in parallel")
+	prCreateCmd.Flags().BoolVar(&prCreateReopen, "reopen", false, "reopen closed PRs instead of creating new ones")
+	prCreateCmd.Flags().BoolVar(&prCreateRecover, "recover", false, "record existing remote PRs in the stack without creating any")
+	prCreateCmd.Flags().BoolVar(&prCreateOpen, "open", false, "open created PRs in the browser")
+	prCreateCmd.Flags().BoolVar(&prCreateAssignSelf, "assign-self", false, "assign yourself to created PRs")
+	prCreateCmd.Flags().BoolVar(&prCreateNoStackSection, "no-stack-section", false, "leave the stack section out of this stack's PR descriptions")
+	prCreateCmd.Flags().BoolVar(&prCreateInheritLabels, "inherit-labels", false, "copy labels from the parent branch's PR")
+	prCreateCmd.Flags().IntSliceVar(&prCreateCloses, "closes", nil, "close these issues when the PR merges (repeatable)")
+	prCreateCmd.Flags().StringVar(&prCreateClosesOn, "closes-on", "", "branch whose PR gets the --closes keywords (default: bottom branch)")
+	prCreateCmd.Flags().StringVar(&prCreateHeadOwner, "head-owner", "", "owner of the fork the branches are pushed to (default: detected from the upstream remote)")
+	prCreateCmd.Flags().StringVar(&prCreateMilestone, "milestone", "", "set this milestone on created PRs")
+	prCreateCmd.Flags().IntVar(&prCreateMaxTitleLength, "max-title-length", defaultMaxTitleLength, "truncate PR titles longer than this")
+	prCreateCmd.Flags().BoolVar(&prCreateTargetStackBase, "target-stack-base", true, "retarget existing PRs to their parent in the stack")
 	prCmd.AddCommand(prCreateCmd)
 }
 
 func runPRCreate(cmd *cobra.Command, args []string) error {
 	stk := RequireStack()
+	if err := applyNoStackSection(cmd, stk, prCreateNoStackSection); err != nil {
+		return err
+	}
 
-	// Get remote URL to detect provider
-	remoteURL, err := Git().Remote("origin")
+	target, err := resolvePRTarget(prCreateHeadOwner)
 	if err != nil {
-		return fmt.Errorf("failed to get remote URL: %w", err)
+		return err
 	}
-
-	provider, err := pr.DetectProvider(remoteURL)
+	provider, err := providerFor(target)
 	if err != nil {
 		return err
 	}
+	headOwner := target.HeadOwner
 
-	// Set up provider with repo info
-	switch p := provider.(type) {
-	case *pr.GitHubProvider:
-		if err := p.SetRepo(remoteURL); err != nil {
+	// The stack's base has to exist where the PRs are opened
+	baseRemote := target.Remote
+
+	fmt.Printf("Using %s provider\n", provider.Name())
+	if headOwner != "" {
+		fmt.Printf("Opening PRs from %s's fork against %s\n", headOwner, target.URL)
+	}
+	fmt.Println()
+
+	if prCreateRecover {
+		return recoverPRs(stk, provider, args)
+	}
+
+	// Fix up PRs that still point at a stale base
+	if prCreateTargetStackBase {
+		if err := retargetStackPRs(stk, provider, nil); err != nil {
 			return err
 		}
-	case *pr.GitLabProvider:
-		if err := p.SetRepo(remoteURL); err != nil {
-			return err
+	}
+
+	bodyText, err := readBodyFile(prCreateBodyFile)
+	if err != nil {
+		return err
+	}
+
+	milestone := checkMilestone(provider, prCreateMilestone)
+	reviewers := newPRReviewers(provider, prCreateReviewers)
+	warnUnsupported(provider, prCreateDraft, prCreateLabels, reviewers)
+
+	var assignees []string
+	if prCreateAssignSelf {
+		user, err := provider.CurrentUser()
+		if err != nil {
+			ui.Warning("Could not determine the current user; PRs will be created without an assignee: %v", err)
+		} else {
+			assignees = []string{user}
 		}
 	}
 
-	fmt.Printf("Using %s provider\n\n", provider.Name())
+	var codeOwners *pr.CodeOwners
+	var self string
+	if prCreateCodeOwners {
+		codeOwners, self = loadCodeOwners(provider)
+	}
 
 	// Determine which branches to create PRs for
 	var branches []stack.Branch
@@ -180,6 +916,21 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 		branches = stk.Branches
 	}
 
+	// Determine which branch gets the closing keywords
+	closesOn := ""
+	if len(prCreateCloses) > 0 {
+		closesOn = prCreateClosesOn
+		if closesOn == "" && len(stk.Branches) > 0 {
+			closesOn = stk.Branches[len(stk.Branches)-1].Name
+		}
+		if stk.FindBranch(closesOn) < 0 {
+			return fmt.Errorf("branch %q not in stack", closesOn)
+		}
+		if !containsBranch(branches, closesOn) {
+			ui.Warning("Not creating a PR for %s; --closes will be ignored", closesOn)
+		}
+	}
+
 	// Collect branch info for stack section
 	var branchInfos []pr.PRBranchInfo
 	for _, b := range stk.Branches {
@@ -193,28 +944,69 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 		branchInfos = append(branchInfos, info)
 	}
 
-	// Create PRs
-	for i, branch := range branches {
-		// Determine base branch
-		var base string
-		idx := stk.FindBranch(branch.Name)
-		if idx == 0 {
-			base = stk.Base
-		} else {
-			base = stk.Branches[idx-1].Name
+	// Record a created PR; shared by the serial and concurrent paths
+	var createdURLs []string
+	record := func(branch string, reviewers []string, newPR *pr.PR, err error) {
+		// The PR exists even if setting up the rest of it failed
+		if errors.Is(err, pr.ErrIncomplete) && newPR != nil {
+			ui.Warning("%v", err)
+			err = nil
 		}
-
-		// Check if PR already exists
-		if branch.PR != nil && branch.PR.Number > 0 {
-			fmt.Printf("%s Skipping %s - PR #%d already exists\n",
-				ui.IconInfo, branch.Name, branch.PR.Number)
-			continue
+		if errors.Is(err, pr.ErrPRExists) {
+			reportExistingPR(branch)
+			return
+		}
+		if err != nil {
+			ui.Error("Failed to create PR for %s: %v", branch, err)
+			return
 		}
 
-		// Check if there's already an open PR for this branch
-		existingPR, err := provider.GetByBranch(branch.Name)
-		if err == nil && existingPR != nil {
-			fmt.Printf("%s Found existing PR #%d for %s\n",
+		// Update stack metadata
+		_ = Manager().UpdatePR(stk, branch, &stack.PR{
+			Number: newPR.Number,
+			URL:    newPR.URL,
+			State:  newPR.State,
+			Title:  newPR.Title,
+			Base:   newPR.Base,
+		})
+
+		// Update branchInfos for subsequent PRs
+		branchInfos[stk.FindBranch(branch)].PR = newPR
+
+		ui.Success("Created PR #%d: %s", newPR.Number, newPR.URL)
+		applyPeopleChanges(provider, newPR.Number, reviewers, nil, assignees)
+		createdURLs = append(createdURLs, newPR.URL)
+	}
+
+	// Recorded PR states may be stale, so always check the remote
+	cache := newPRCache(true)
+	defer cache.save()
+
+	// Create PRs
+	var pending []pendingPR
+	for _, branch := range branches {
+		// Determine base branch
+		base := stk.GetPRBase(branch.Name)
+
+		// Check if PR already exists; closed ones may be replaced
+		skip, err := skipTrackedPR(stk, provider, cache, branch, prCreateReopen, true)
+		if err != nil {
+			return err
+		}
+		if skip {
+			if branch.Name == closesOn {
+				ui.Warning("--closes only applies to new PRs; add the keywords to PR #%d manually", branch.PR.Number)
+			}
+			continue
+		}
+		if branch.PR != nil && branch.PR.Number > 0 {
+			branchInfos[stk.FindBranch(branch.Name)].PR = nil
+		}
+
+		// Check if there's already an open PR for this branch
+		existingPR, err := provider.GetByBranch(branch.Name)
+		if err == nil && existingPR != nil {
+			fmt.Printf("%s Found existing PR #%d for %s\n",
 				ui.IconInfo, existingPR.Number, branch.Name)
 
 			// Update stack metadata
@@ -223,18 +1015,35 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 				URL:    existingPR.URL,
 				State:  existingPR.State,
 				Title:  existingPR.Title,
+				Base:   existingPR.Base,
 			})
 			continue
 		}
 
-		// Determine title
-		title := prCreateTitle
-		if title == "" {
-			title = branch.Name
+		if err := requireRemoteBase(stk, base, baseRemote); err != nil {
+			return err
+		}
+		if skipEmptyBranch(branch.Name, base) {
+			continue
 		}
 
+		// Determine title
+		title := prTitle(prCreateTitle, branch.Name, base, prCreateFill, prCreateMaxTitleLength)
+
 		// Generate body with stack section
-		body := pr.GenerateStackSection(stk.Name, branchInfos, branch.Name)
+		body := newPRBody(stk, bodyText, branchInfos, branch.Name)
+
+		// Determine labels
+		labels := prCreateLabels
+		if prCreateInheritLabels {
+			labels = mergeLabels(labels, parentPRLabels(stk, provider, branch.Name))
+		}
+
+		// Closing keywords only go on one PR
+		var closeIssues []int
+		if branch.Name == closesOn {
+			closeIssues = prCreateCloses
+		}
 
 		fmt.Printf("%s Creating PR for %s → %s\n", ui.IconArrow, branch.Name, base)
 
@@ -244,55 +1053,140 @@ func runPRCreate(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		// Create the PR
-		newPR, err := provider.Create(pr.CreateOptions{
-			Title:     title,
-			Body:      body,
-			Head:      branch.Name,
-			Base:      base,
-			Draft:     prCreateDraft,
-			Reviewers: prCreateReviewers,
-		})
-		if err != nil {
-			ui.Error("Failed to create PR for %s: %v", branch.Name, err)
+		branchReviewers := reviewers
+		if codeOwners != nil {
+			branchReviewers = mergeLabels(branchReviewers, branchCodeOwners(stk, codeOwners, branch.Name, self))
+		}
+
+		opts := pr.CreateOptions{
+			Title:       title,
+			Body:        body,
+			Head:        branch.Name,
+			Base:        base,
+			Draft:       prCreateDraft,
+			Reviewers:   branchReviewers,
+			Labels:      labels,
+			Milestone:   milestone,
+			HeadOwner:   headOwner,
+			CloseIssues: closeIssues,
+		}
+		if prCreateJobs > 1 {
+			pending = append(pending, pendingPR{branch: branch.Name, opts: opts})
 			continue
 		}
 
-		// Update stack metadata
-		_ = Manager().UpdatePR(stk, branch.Name, &stack.PR{
-			Number: newPR.Number,
-			URL:    newPR.URL,
-			State:  newPR.State,
-			Title:  newPR.Title,
-		})
+		// Create the PR
+		newPR, err := createPR(provider, opts)
+		record(branch.Name, opts.Reviewers, newPR, err)
+	}
 
-		// Update branchInfos for subsequent PRs
-		branchInfos[i].PR = newPR
+	if len(pending) > 0 {
+		fmt.Println()
+		fmt.Printf("%s Creating %d PR(s), %d at a time...\n", ui.IconArrow, len(pending), prCreateJobs)
+		for k, result := range createPRsConcurrently(provider, pending, prCreateJobs) {
+			record(pending[k].branch, pending[k].opts.Reviewers, result.pr, result.err)
+		}
 
-		ui.Success("Created PR #%d: %s", newPR.Number, newPR.URL)
+		// Fill in the PR numbers the stack sections couldn't know yet
+		if len(createdURLs) > 0 && stackSectionEnabled(stk) {
+			fmt.Println()
+			fmt.Println(ui.IconArrow + " Updating PR descriptions...")
+			if err := UpdateAllPRDescriptions(stk, provider); err != nil {
+				ui.Warning("Failed to update PR descriptions: %v", err)
+			}
+		}
 	}
 
 	fmt.Println()
 	ui.Success("PR creation complete")
+
+	if prCreateOpen && len(createdURLs) > 0 {
+		fmt.Println()
+		openURLs(createdURLs)
+	}
 	return nil
 }
 
+// upstreamRemote is the conventional name of the original repository's
+// remote in a fork checkout.
+const upstreamRemote = "upstream"
+
+// forkTarget returns the remote URL PRs should be opened on and, when origin
+// is a fork of it, the fork's owner. Without an upstream remote owned by
+// someone else, PRs go to origin and the owner is empty.
+func forkTarget(originURL string) (prURL, headOwner string) {
+	upstreamURL, err := Git().Remote(upstreamRemote)
+	if err != nil {
+		return originURL, ""
+	}
+
+	originOwner, _, err := pr.ParseRemoteURL(originURL)
+	if err != nil {
+		return originURL, ""
+	}
+	upstreamOwner, _, err := pr.ParseRemoteURL(upstreamURL)
+	if err != nil || upstreamOwner == originOwner {
+		return originURL, ""
+	}
+	return upstreamURL, originOwner
+}
+
+// warnUnsupported warns once about PR options the provider fakes or
+// ignores, before any PRs are created.
+func warnUnsupported(provider pr.Provider, draft bool, labels, reviewers []string) {
+	if draft && !provider.SupportsDraft() {
+		ui.Warning("%s has no native draft PRs; titles get a \"Draft:\" prefix instead", provider.Name())
+	}
+	if len(labels) > 0 && !provider.SupportsLabels() {
+		ui.Warning("%s doesn't support labels; --label will be ignored", provider.Name())
+	}
+	if len(reviewers) > 0 && !provider.SupportsReviewers() {
+		ui.Warning("%s doesn't support review requests; --reviewer will be ignored", provider.Name())
+	}
+}
+
+// containsBranch reports whether branches includes a branch with the given name.
+func containsBranch(branches []stack.Branch, name string) bool {
+	for _, b := range branches {
+		if b.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 var prViewCmd = &cobra.Command{
-	Use:   "view [branch]",
+	Use:   "view [branch | number]",
 	Short: "Open PR in browser",
 	Long: `Open the pull request for a branch in your browser.
 
-Without arguments, opens the PR for the current branch.`,
+Without arguments, opens the PR for the current branch. A PR number opens
+that PR, whether or not the stack tracks it.
+With --all, opens every tracked PR in the stack, starting from the
+branch closest to the base. When stdout is not a terminal, or a browser
+can't be launched, the URLs are printed instead.
+
+Examples:
+  stk pr view               # PR for the current branch
+  stk pr view feature/auth  # PR for a stack branch
+  stk pr view 42            # PR #42`,
 	RunE: runPRView,
 }
 
+var prViewAll bool
+
 func init() {
+	prViewCmd.Flags().BoolVar(&prViewAll, "all", false, "open PRs for every branch in the stack")
 	prCmd.AddCommand(prViewCmd)
 }
 
 func runPRView(cmd *cobra.Command, args []string) error {
 	stk := RequireStack()
 
+	if prViewAll {
+		return viewAllPRs(stk)
+	}
+
 	var branchName string
 	if len(args) > 0 {
 		branchName = args[0]
@@ -306,6 +1200,9 @@ func runPRView(cmd *cobra.Command, args []string) error {
 
 	idx := stk.FindBranch(branchName)
 	if idx < 0 {
+		if number, err := strconv.Atoi(strings.TrimPrefix(branchName, "#")); err == nil && number > 0 {
+			return viewPRNumber(stk, number)
+		}
 		return fmt.Errorf("branch %q not in stack", branchName)
 	}
 
@@ -318,6 +1215,79 @@ func runPRView(cmd *cobra.Command, args []string) error {
 	return openBrowser(branch.PR.URL)
 }
 
+// viewPRNumber opens a PR by number, using the stack's cached URL if it
+// tracks the PR and building the URL from the repository otherwise.
+func viewPRNumber(stk *stack.Stack, number int) error {
+	for _, b := range stk.Branches {
+		if b.PR != nil && b.PR.Number == number && b.PR.URL != "" {
+			fmt.Printf("Opening %s (%s)\n", b.PR.URL, b.Name)
+			return openBrowser(b.PR.URL)
+		}
+	}
+
+	provider, err := getProvider()
+	if err != nil {
+		return err
+	}
+	url := provider.URL(number)
+	fmt.Printf("Opening %s\n", url)
+	return openBrowser(url)
+}
+
+// viewAllPRs opens every tracked PR URL in stack order, or prints them
+// when not attached to a terminal.
+func viewAllPRs(stk *stack.Stack) error {
+	var urls []string
+	for _, b := range stk.Branches {
+		if b.PR != nil && b.PR.URL != "" {
+			urls = append(urls, b.PR.URL)
+		}
+	}
+	if len(urls) == 0 {
+		return fmt.Errorf("no PRs found in stack; run 'stk pr create' first")
+	}
+
+	openURLs(urls)
+	return nil
+}
+
+// openURLs opens each URL in the browser, or prints them when not attached
+// to a terminal or when the browser can't be started.
+func openURLs(urls []string) {
+	interactive := isTerminal(os.Stdout)
+	for i, url := range urls {
+		if !interactive {
+			fmt.Println(url)
+			continue
+		}
+
+		fmt.Printf("Opening %s\n", url)
+		if err := openBrowser(url); err != nil {
+			ui.Warning("Could not open browser: %v", err)
+			fmt.Println(url)
+			interactive = false
+			continue
+		}
+
+		// Give the browser time to register each tab
+		if i < len(urls)-1 {
+			time.Sleep(300 * time.Millisecond)
+		}
+	}
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	return ui.IsTerminal(f)
+}
+
+// terminalWidth returns the number of columns of the controlling terminal,
+// or 0 if it can't be determined.
+func terminalWidth() int {
+	_, cols := ui.TerminalSize()
+	return cols
+}
+
 func openBrowser(url string) error {
 	var cmd string
 	var args []string
@@ -347,41 +1317,125 @@ var prStatusCmd = &cobra.Command{
 	Short: "Show PR status for all branches",
 	Long: `Display the status of all pull requests in the stack.
 
-Shows PR numbers, states, and URLs for each branch.`,
+Shows PR numbers, states, and URLs for each branch.
+
+Use --state to show only PRs in a given state (combine with --refresh
+to filter on the current remote state).
+
+--refresh reuses PR state fetched within the last pr_cache_ttl (60s by
+default, set in .stk.yaml); use --force-refresh to bypass the cache.
+
+Use --checks to add a column with the CI status of each PR, and
+--mergeable to add one showing whether it merges cleanly into its base
+(⚠ marks conflicts; GitHub and GitLab compute this in the background, so
+new PRs may show "computing" for a moment).
+
+Use --open-failing to also open the PRs whose checks are failing in the
+browser (their URLs are printed instead when not in a terminal).
+
+Use --author to show only PRs opened by a given user, or --mine for your
+own. Both refresh from the remote, since authors aren't known until then.
+Once known, authors are shown in an AUTHOR column.
+
+Examples:
+  stk pr status                        # All branches
+  stk pr status --mine                 # Only my PRs
+  stk pr status --state open           # Only open PRs
+  stk pr status --state merged --refresh
+  stk pr status --open-failing         # Triage CI failures`,
 	Aliases: []string{"st"},
 	RunE:    runPRStatus,
 }
 
-var prStatusRefresh bool
+var (
+	prStatusRefresh      bool
+	prStatusState        string
+	prStatusChecks       bool
+	prStatusForceRefresh bool
+	prStatusOpenFailing  bool
+	prStatusMergeable    bool
+	prStatusAuthor       string
+	prStatusMine         bool
+)
 
 func init() {
 	prStatusCmd.Flags().BoolVar(&prStatusRefresh, "refresh", false, "refresh PR status from remote")
+	prStatusCmd.Flags().BoolVar(&prStatusForceRefresh, "force-refresh", false, "refresh from remote even if the PR cache is fresh")
+	prStatusCmd.Flags().BoolVar(&prStatusChecks, "checks", false, "show CI check status")
+	prStatusCmd.Flags().BoolVar(&prStatusMergeable, "mergeable", false, "show whether PRs merge cleanly")
+	prStatusCmd.Flags().BoolVar(&prStatusOpenFailing, "open-failing", false, "open PRs with failing checks in the browser")
+	prStatusCmd.Flags().StringVar(&prStatusState, "state", "", "only show PRs in this state (open, merged, closed, draft)")
+	prStatusCmd.Flags().StringVar(&prStatusAuthor, "author", "", "only show PRs opened by this user")
+	prStatusCmd.Flags().BoolVar(&prStatusMine, "mine", false, "only show PRs you opened")
+	prStatusCmd.MarkFlagsMutuallyExclusive("author", "mine")
 	prCmd.AddCommand(prStatusCmd)
 }
 
+// prStatusRow is one line of the 'pr status' table.
+type prStatusRow struct {
+	branch    string
+	pr        string
+	state     string
+	author    string
+	checks    string
+	mergeable string
+	url       string
+}
+
+// Widths that fit every rendered checks and mergeable badge.
+const (
+	prStatusChecksWidth    = 10
+	prStatusMergeableWidth = 11
+)
+
 func runPRStatus(cmd *cobra.Command, args []string) error {
 	stk := RequireStack()
 
+	switch prStatusState {
+	case "", "open", "merged", "closed", "draft":
+	default:
+		return fmt.Errorf("invalid --state %q (expected open, merged, closed or draft)", prStatusState)
+	}
+	if prStatusForceRefresh {
+		prStatusRefresh = true
+	}
+	if prStatusOpenFailing {
+		prStatusChecks = true
+	}
+
 	provider, err := getProvider()
 	if err != nil {
 		return err
 	}
 
+	// Authors are only known after a refresh
+	author := prStatusAuthor
+	if prStatusMine {
+		author, err = provider.CurrentUser()
+		if err != nil {
+			return fmt.Errorf("failed to look up the current user: %w", err)
+		}
+	}
+	if author != "" {
+		prStatusRefresh = true
+	}
+
 	fmt.Printf("%s Stack: %s%s%s\n\n", ui.IconStack, ui.Bold, stk.Name, ui.Reset)
 
-	// Table header
-	fmt.Printf("%-30s %-8s %-12s %s\n", "BRANCH", "PR", "STATE", "URL")
-	fmt.Println(strings.Repeat("-", 80))
+	cache := newPRCache(prStatusForceRefresh)
+	defer cache.save()
 
+	// Collect rows first so the columns fit the widest values
+	var rows []prStatusRow
+	var failingURLs []string
+	filtered, otherAuthors, computing := 0, 0, 0
 	for _, branch := range stk.Branches {
-		prNum := "-"
-		state := "none"
-		url := "-"
+		row := prStatusRow{branch: branch.Name, pr: "-", state: "none", url: "-"}
 
 		if branch.PR != nil && branch.PR.Number > 0 {
 			// Optionally refresh from remote
 			if prStatusRefresh {
-				remotePR, err := provider.Get(branch.PR.Number)
+				remotePR, err := cache.get(provider, branch.PR.Number)
 				if err == nil && remotePR != nil {
 					// Update local cache
 					_ = Manager().UpdatePR(stk, branch.Name, &stack.PR{
@@ -389,34 +1443,168 @@ func runPRStatus(cmd *cobra.Command, args []string) error {
 						URL:    remotePR.URL,
 						State:  remotePR.State,
 						Title:  remotePR.Title,
+						Base:   remotePR.Base,
+						Author: remotePR.Author,
 					})
-					prNum = fmt.Sprintf("#%d", remotePR.Number)
-					state = remotePR.State
-					url = remotePR.URL
+					row.pr = fmt.Sprintf("#%d", remotePR.Number)
+					row.state = remotePR.State
+					row.author = remotePR.Author
+					row.url = remotePR.URL
 				}
 			} else {
-				prNum = fmt.Sprintf("#%d", branch.PR.Number)
-				state = branch.PR.State
+				row.pr = fmt.Sprintf("#%d", branch.PR.Number)
+				row.state = branch.PR.State
+				row.author = branch.PR.Author
 				if branch.PR.URL != "" {
-					url = branch.PR.URL
+					row.url = branch.PR.URL
 				}
 			}
 		}
 
-		// Color state
-		stateColored := state
-		switch state {
+		if prStatusState != "" && row.state != prStatusState {
+			filtered++
+			continue
+		}
+		if author != "" && !strings.EqualFold(row.author, author) {
+			otherAuthors++
+			continue
+		}
+
+		if prStatusChecks {
+			row.checks = string(pr.ChecksNotConfigured)
+			if branch.PR != nil && branch.PR.Number > 0 && row.state != "merged" && row.state != "closed" {
+				if c, err := provider.Checks(branch.PR.Number); err == nil {
+					row.checks = string(c)
+				} else {
+					row.checks = "unknown"
+				}
+			}
+		}
+
+		if prStatusMergeable {
+			row.mergeable = "none"
+			if branch.PR != nil && branch.PR.Number > 0 && row.state != "merged" && row.state != "closed" {
+				ok, reason, err := provider.Mergeable(branch.PR.Number)
+				switch {
+				case err != nil:
+					row.mergeable = "unknown"
+				case ok:
+					row.mergeable = "yes"
+				default:
+					row.mergeable = reason
+				}
+				if row.mergeable == pr.MergeComputing {
+					computing++
+				}
+			}
+		}
+
+		rows = append(rows, row)
+		if row.checks == string(pr.ChecksFailing) && row.url != "-" {
+			failingURLs = append(failingURLs, row.url)
+		}
+	}
+
+	// Column widths, including the single space after each column
+	branchW, prW, stateW, authorW := len("BRANCH"), len("PR"), len("STATE"), 0
+	for _, row := range rows {
+		branchW = max(branchW, len(row.branch))
+		prW = max(prW, len(row.pr))
+		stateW = max(stateW, len(row.state))
+		if row.author != "" {
+			authorW = max(authorW, len("AUTHOR"), len(row.author))
+		}
+	}
+	urlCol := branchW + 1 + prW + 1 + stateW + 1
+	if authorW > 0 {
+		urlCol += authorW + 1
+	}
+	if prStatusChecks {
+		urlCol += prStatusChecksWidth + 1
+	}
+	if prStatusMergeable {
+		urlCol += prStatusMergeableWidth + 1
+	}
+
+	// Keep URLs on one line in a terminal
+	urlW := 0
+	if isTerminal(os.Stdout) {
+		if cols := terminalWidth(); cols > urlCol {
+			urlW = cols - urlCol
+		}
+	}
+
+	// Table header
+	header := fmt.Sprintf("%-*s %-*s %-*s ", branchW, "BRANCH", prW, "PR", stateW, "STATE")
+	if authorW > 0 {
+		header += fmt.Sprintf("%-*s ", authorW, "AUTHOR")
+	}
+	if prStatusChecks {
+		header += fmt.Sprintf("%-*s ", prStatusChecksWidth, "CHECKS")
+	}
+	if prStatusMergeable {
+		header += fmt.Sprintf("%-*s ", prStatusMergeableWidth, "MERGEABLE")
+	}
+	fmt.Println(header + "URL")
+	ruleW := urlCol + len("URL")
+	for _, row := range rows {
+		ruleW = max(ruleW, urlCol+len(row.url))
+	}
+	if urlW > 0 {
+		ruleW = min(ruleW, urlCol+urlW)
+	}
+	fmt.Println(strings.Repeat("-", ruleW))
+
+	for _, row := range rows {
+		// Pad before coloring so escape codes don't count toward the width
+		state := fmt.Sprintf("%-*s", stateW, row.state)
+		switch row.state {
 		case "open":
-			stateColored = ui.Green + state + ui.Reset
+			state = ui.Green + state + ui.Reset
 		case "merged":
-			stateColored = ui.Magenta + state + ui.Reset
+			state = ui.Magenta + state + ui.Reset
 		case "closed":
-			stateColored = ui.Red + state + ui.Reset
+			state = ui.Red + state + ui.Reset
 		case "draft":
-			stateColored = ui.Dim + state + ui.Reset
+			state = ui.Dim + state + ui.Reset
+		}
+
+		line := fmt.Sprintf("%-*s %-*s %s ", branchW, row.branch, prW, row.pr, state)
+		if authorW > 0 {
+			line += fmt.Sprintf("%-*s ", authorW, row.author)
+		}
+		if prStatusChecks {
+			line += ui.ChecksBadge(row.checks, prStatusChecksWidth) + " "
+		}
+		if prStatusMergeable {
+			line += ui.MergeableBadge(row.mergeable, prStatusMergeableWidth) + " "
+		}
+		fmt.Println(line + ui.Truncate(row.url, urlW))
+	}
+
+	if computing > 0 {
+		fmt.Println()
+		ui.DimText("Mergeability of %d PR(s) is still being computed; run again in a moment", computing)
+	}
+
+	if filtered > 0 {
+		fmt.Println()
+		ui.DimText("%d branch(es) not in state %q hidden", filtered, prStatusState)
+	}
+	if otherAuthors > 0 {
+		if filtered == 0 {
+			fmt.Println()
 		}
+		ui.DimText("%d branch(es) without a PR by %s hidden", otherAuthors, author)
+	}
 
-		fmt.Printf("%-30s %-8s %-12s %s\n", branch.Name, prNum, stateColored, url)
+	if prStatusOpenFailing {
+		fmt.Println()
+		if len(failingURLs) == 0 {
+			ui.Success("No PRs with failing checks")
+		} else {
+			openURLs(failingURLs)
+		}
 	}
 
 	return nil
@@ -434,13 +1622,26 @@ var prUpdateCmd = &cobra.Command{
 This updates the "Stack" section in each PR description to reflect
 the current state of all PRs in the stack.
 
+Use --reviewer, --remove-reviewer and --assignee to also adjust the
+people on existing PRs.
+
 Examples:
-  stk pr update              # Update all PRs
-  stk pr update feature-api  # Update specific PR only`,
+  stk pr update                             # Update all PRs
+  stk pr update feature-api                 # Update specific PR only
+  stk pr update feature-api --reviewer bob  # Also request a review from bob`,
 	RunE: runPRUpdate,
 }
 
+var (
+	prUpdateReviewers       []string
+	prUpdateRemoveReviewers []string
+	prUpdateAssignees       []string
+)
+
 func init() {
+	prUpdateCmd.Flags().StringSliceVar(&prUpdateReviewers, "reviewer", nil, "request reviews from these users")
+	prUpdateCmd.Flags().StringSliceVar(&prUpdateRemoveReviewers, "remove-reviewer", nil, "remove review requests for these users")
+	prUpdateCmd.Flags().StringSliceVar(&prUpdateAssignees, "assignee", nil, "assign these users")
 	prCmd.AddCommand(prUpdateCmd)
 }
 
@@ -475,14 +1676,21 @@ func runPRUpdate(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		// Generate new body with updated stack section
-		body := pr.GenerateStackSection(stk.Name, branchInfos, branch.Name)
-
 		fmt.Printf("%s Updating PR #%d (%s)...\n", ui.IconArrow, branch.PR.Number, branch.Name)
-		if err := provider.Update(branch.PR.Number, pr.UpdateOptions{Body: &body}); err != nil {
-			ui.Error("Failed to update PR #%d: %v", branch.PR.Number, err)
-			continue
+
+		// Replace the stack section, keeping the text above it
+		if stackSectionEnabled(stk) {
+			body, err := updatedBody(provider, branch.PR.Number, stackSection(stk, branchInfos, branch.Name))
+			if err != nil {
+				ui.Error("Not updating PR #%d: %v", branch.PR.Number, err)
+				continue
+			}
+			if err := provider.Update(branch.PR.Number, pr.UpdateOptions{Body: &body}); err != nil {
+				ui.Error("Failed to update PR #%d: %v", branch.PR.Number, err)
+				continue
+			}
 		}
+		applyPeopleChanges(provider, branch.PR.Number, prUpdateReviewers, prUpdateRemoveReviewers, prUpdateAssignees)
 		ui.Success("Updated PR #%d", branch.PR.Number)
 	}
 
@@ -490,3 +1698,674 @@ func runPRUpdate(cmd *cobra.Command, args []string) error {
 	ui.Success("PR update complete")
 	return nil
 }
+
+// newPRReviewers returns the reviewers to request on new PRs: those given
+// with --reviewer followed by the default reviewers (STK_REVIEWERS, or
+// reviewers in .stk.yaml), resolved and without duplicates.
+func newPRReviewers(provider pr.Provider, flagReviewers []string) []string {
+	return resolveReviewers(provider, mergeLabels(flagReviewers, Config().DefaultReviewers()))
+}
+
+// resolveReviewers replaces "@me" with the current user and drops team
+// entries ("team:<slug>") on providers without team review requests.
+// Duplicates are removed.
+func resolveReviewers(provider pr.Provider, reviewers []string) []string {
+	var resolved []string
+	for _, r := range reviewers {
+		switch {
+		case r == "@me":
+			user, err := provider.CurrentUser()
+			if err != nil {
+				ui.Warning("Could not determine the current user; not requesting a review from @me: %v", err)
+				continue
+			}
+			r = user
+		case strings.HasPrefix(r, pr.TeamReviewerPrefix):
+			if !provider.SupportsTeamReviewers() {
+				ui.Warning("%s doesn't support team reviewers; skipping %s", provider.Name(), r)
+				continue
+			}
+		}
+		resolved = append(resolved, r)
+	}
+	return mergeLabels(resolved, nil)
+}
+
+// applyPeopleChanges adds and removes reviewers and adds assignees on a PR,
+// warning about (but not failing on) individual errors.
+func applyPeopleChanges(provider pr.Provider, number int, reviewers, removeReviewers, assignees []string) {
+	if len(reviewers) > 0 {
+		fmt.Printf("  Requesting review from %s\n", strings.Join(reviewers, ", "))
+		if err := provider.AddReviewers(number, reviewers); err != nil {
+			ui.Warning("Failed to add reviewers to PR #%d: %v", number, err)
+		}
+	}
+	if len(removeReviewers) > 0 {
+		fmt.Printf("  Removing review request for %s\n", strings.Join(removeReviewers, ", "))
+		if err := provider.RemoveReviewers(number, removeReviewers); err != nil {
+			ui.Warning("Failed to remove reviewers from PR #%d: %v", number, err)
+		}
+	}
+	if len(assignees) > 0 {
+		fmt.Printf("  Assigning %s\n", strings.Join(assignees, ", "))
+		if err := provider.AddAssignees(number, assignees); err != nil {
+			ui.Warning("Failed to add assignees to PR #%d: %v", number, err)
+		}
+	}
+}
+
+// ============================================================================
+// pr diff - Print a PR's diff as the provider sees it
+// ============================================================================
+
+var prDiffCmd = &cobra.Command{
+	Use:   "diff [branch|number]",
+	Short: "Print a PR's diff from the provider",
+	Long: `Print the diff of a branch's PR (the current branch by default) as
+computed by GitHub or GitLab, without checking anything out. This shows
+what reviewers see, even while the local branch is mid-rebase or has
+unpushed changes.
+
+A PR number (e.g. 123 or #123) selects a PR directly.
+
+Long diffs are shown in the pager; use --no-pager to print them directly.
+
+Examples:
+  stk pr diff                # Diff of the current branch's PR
+  stk pr diff feature-api    # Diff of another branch's PR
+  stk pr diff 123 > pr.patch # Save a PR's diff`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRDiff,
+}
+
+func init() {
+	prCmd.AddCommand(prDiffCmd)
+}
+
+func runPRDiff(cmd *cobra.Command, args []string) error {
+	stk := RequireStack()
+
+	var branchName string
+	if len(args) > 0 {
+		branchName = args[0]
+	} else {
+		var err error
+		branchName, err = Git().CurrentBranch()
+		if err != nil {
+			return err
+		}
+	}
+
+	var number int
+	if idx := stk.FindBranch(branchName); idx >= 0 {
+		branch := stk.Branches[idx]
+		if branch.PR == nil || branch.PR.Number == 0 {
+			return fmt.Errorf("no PR found for %s; run 'stk pr create' first", branchName)
+		}
+		number = branch.PR.Number
+	} else if n, err := strconv.Atoi(strings.TrimPrefix(branchName, "#")); err == nil && n > 0 {
+		number = n
+	} else {
+		return fmt.Errorf("branch %q not in stack", branchName)
+	}
+
+	provider, err := getProvider()
+	if err != nil {
+		return err
+	}
+
+	diff, err := provider.GetDiff(number)
+	if err := authFailure(provider, err); err != nil {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get diff of PR #%d: %w", number, err)
+	}
+	if diff == "" {
+		ui.Info("PR #%d has no changes", number)
+		return nil
+	}
+
+	if isTerminal(os.Stdout) {
+		diff = colorDiff(diff)
+	}
+	page(diff)
+	return nil
+}
+
+// colorDiff colors the headers, hunk markers and changed lines of a
+// unified diff the way 'git diff' does.
+func colorDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git"), strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "+++ "):
+			lines[i] = ui.Bold + line + ui.Reset
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = ui.Cyan + line + ui.Reset
+		case strings.HasPrefix(line, "+"):
+			lines[i] = ui.Green + line + ui.Reset
+		case strings.HasPrefix(line, "-"):
+			lines[i] = ui.Red + line + ui.Reset
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ============================================================================
+// pr request-review - Manage reviewers on existing PRs
+// ============================================================================
+
+var prRequestReviewCmd = &cobra.Command{
+	Use:   "request-review [branch]",
+	Short: "Request reviews on existing PRs",
+	Long: `Add or remove reviewers (and add assignees) on existing PRs without
+touching their descriptions.
+
+Without a branch, applies to every PR in the stack.
+
+Examples:
+  stk pr request-review --reviewer alice,bob            # All PRs
+  stk pr request-review feature-api --reviewer alice    # One PR
+  stk pr request-review --remove-reviewer bob           # Withdraw a request`,
+	RunE: runPRRequestReview,
+}
+
+var (
+	prReviewReviewers       []string
+	prReviewRemoveReviewers []string
+	prReviewAssignees       []string
+)
+
+func init() {
+	prRequestReviewCmd.Flags().StringSliceVar(&prReviewReviewers, "reviewer", nil, "request reviews from these users")
+	prRequestReviewCmd.Flags().StringSliceVar(&prReviewRemoveReviewers, "remove-reviewer", nil, "remove review requests for these users")
+	prRequestReviewCmd.Flags().StringSliceVar(&prReviewAssignees, "assignee", nil, "assign these users")
+	prCmd.AddCommand(prRequestReviewCmd)
+}
+
+func runPRRequestReview(cmd *cobra.Command, args []string) error {
+	stk := RequireStack()
+
+	if len(prReviewReviewers) == 0 && len(prReviewRemoveReviewers) == 0 && len(prReviewAssignees) == 0 {
+		return fmt.Errorf("specify at least one of --reviewer, --remove-reviewer or --assignee")
+	}
+
+	provider, err := getProvider()
+	if err != nil {
+		return err
+	}
+	reviewers := resolveReviewers(provider, prReviewReviewers)
+	removeReviewers := resolveReviewers(provider, prReviewRemoveReviewers)
+
+	var branches []stack.Branch
+	if len(args) > 0 {
+		idx := stk.FindBranch(args[0])
+		if idx < 0 {
+			return fmt.Errorf("branch %q not in stack", args[0])
+		}
+		branches = []stack.Branch{stk.Branches[idx]}
+	} else {
+		branches = stk.Branches
+	}
+
+	for _, branch := range branches {
+		if branch.PR == nil || branch.PR.Number == 0 {
+			fmt.Printf("%s Skipping %s - no PR found\n", ui.IconInfo, branch.Name)
+			continue
+		}
+		if branch.PR.State == "merged" || branch.PR.State == "closed" {
+			continue
+		}
+
+		fmt.Printf("%s PR #%d (%s)\n", ui.IconArrow, branch.PR.Number, branch.Name)
+		applyPeopleChanges(provider, branch.PR.Number, reviewers, removeReviewers, prReviewAssignees)
+	}
+
+	fmt.Println()
+	ui.Success("Review requests updated")
+	return nil
+}
+
+// ============================================================================
+// pr close - Close a PR, optionally abandoning its branch
+// ============================================================================
+
+var prCloseCmd = &cobra.Command{
+	Use:   "close [branch]",
+	Short: "Close a PR without merging it",
+	Long: `Close the pull request for a branch (the current branch by default).
+
+The branch stays in the stack unless --remove is given, so a later
+'stk submit' pushes it again and opens a new PR.
+
+Use --delete-branch to also delete the branch on the remote. PRs that
+target the branch would be closed by the provider when it's deleted, so
+with children in the stack this requires --remove, which first retargets
+the child's PR to the closed branch's parent.
+
+Use --remove to take the branch out of the stack, and --delete-local to
+also delete the local branch.
+
+Examples:
+  stk pr close                                  # Close the current branch's PR
+  stk pr close feature-old --delete-branch --remove --delete-local`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRClose,
+}
+
+var (
+	prCloseDeleteBranch bool
+	prCloseRemove       bool
+	prCloseDeleteLocal  bool
+)
+
+func init() {
+	prCloseCmd.Flags().BoolVar(&prCloseDeleteBranch, "delete-branch", false, "delete the remote branch after closing")
+	prCloseCmd.Flags().BoolVar(&prCloseRemove, "remove", false, "remove the branch from the stack")
+	prCloseCmd.Flags().BoolVar(&prCloseDeleteLocal, "delete-local", false, "delete the local branch (requires --remove)")
+	prCmd.AddCommand(prCloseCmd)
+}
+
+func runPRClose(cmd *cobra.Command, args []string) error {
+	stk := RequireStack()
+
+	if prCloseDeleteLocal && !prCloseRemove {
+		return fmt.Errorf("--delete-local requires --remove, since the stack would still reference the branch")
+	}
+
+	var branchName string
+	if len(args) > 0 {
+		branchName = args[0]
+	} else {
+		var err error
+		branchName, err = Git().CurrentBranch()
+		if err != nil {
+			return err
+		}
+	}
+
+	idx := stk.FindBranch(branchName)
+	if idx < 0 {
+		return fmt.Errorf("branch %q not in stack", branchName)
+	}
+
+	branch := stk.Branches[idx]
+	if branch.PR == nil || branch.PR.Number == 0 {
+		return fmt.Errorf("no PR found for %s", branchName)
+	}
+	if branch.PR.State == "merged" {
+		return fmt.Errorf("PR #%d is already merged", branch.PR.Number)
+	}
+
+	provider, err := getProvider()
+	if err != nil {
+		return err
+	}
+
+	// Deleting the remote branch would close the PRs based on it
+	children := stk.GetChildren(branchName)
+	if prCloseDeleteBranch && !prCloseRemove {
+		for _, childName := range children {
+			child := stk.Branches[stk.FindBranch(childName)]
+			if child.PR != nil && child.PR.Number > 0 && child.PR.State != "merged" && child.PR.State != "closed" {
+				return fmt.Errorf("PR #%d (%s) targets %s and would be closed with it; add --remove to retarget it first",
+					child.PR.Number, childName, branchName)
+			}
+		}
+	}
+
+	if branch.PR.State == "closed" {
+		fmt.Printf("%s PR #%d is already closed\n", ui.IconInfo, branch.PR.Number)
+	} else {
+		fmt.Printf("%s Closing PR #%d (%s)...\n", ui.IconArrow, branch.PR.Number, branchName)
+		if err := provider.Close(branch.PR.Number); err != nil {
+			return fmt.Errorf("failed to close PR #%d: %w", branch.PR.Number, err)
+		}
+		forgetCachedPR(branch.PR.Number)
+		closed := *branch.PR
+		closed.State = "closed"
+		_ = Manager().UpdatePR(stk, branchName, &closed)
+		ui.Success("Closed PR #%d", branch.PR.Number)
+	}
+
+	if prCloseRemove {
+		if err := Manager().RemoveBranch(stk, branchName); err != nil {
+			return err
+		}
+		fmt.Printf("  Removed %s from stack\n", branchName)
+
+		for _, childName := range children {
+			child := stk.Branches[stk.FindBranch(childName)]
+			if child.PR == nil || child.PR.Number == 0 {
+				continue
+			}
+			newBase := stk.GetPRBase(childName)
+			fmt.Printf("  Retargeting PR #%d to %s\n", child.PR.Number, newBase)
+			if err := provider.Retarget(child.PR.Number, newBase); err != nil {
+				ui.Warning("Failed to retarget PR #%d: %v", child.PR.Number, err)
+			} else {
+				recordPRBase(stk, childName, newBase)
+			}
+		}
+	}
+
+	if prCloseDeleteBranch {
+		fmt.Printf("  Deleting remote branch %s\n", branchName)
+		err := provider.DeleteBranch(branchName)
+		switch {
+		case errors.Is(err, pr.ErrNotFound):
+			fmt.Println(ui.Dim + "  Already deleted on the remote" + ui.Reset)
+		case err != nil:
+			ui.Warning("Failed to delete remote branch %s: %v", branchName, err)
+		}
+		if err == nil || errors.Is(err, pr.ErrNotFound) {
+			// Nothing on the remote references it anymore
+			_ = Git().DeleteRemoteTrackingBranch("origin", branchName)
+		}
+	}
+
+	if prCloseDeleteLocal {
+		if current, _ := Git().CurrentBranch(); current == branchName {
+			parent := stk.Base
+			if idx > 0 {
+				parent = stk.Branches[idx-1].Name
+			}
+			if err := Git().Checkout(parent); err != nil {
+				return fmt.Errorf("failed to leave %s: %w", branchName, err)
+			}
+		}
+		fmt.Printf("  Deleting local branch %s\n", branchName)
+		if err := Git().DeleteBranch(branchName, true); err != nil {
+			ui.Warning("Failed to delete branch %s: %v", branchName, err)
+		}
+	}
+
+	if prCloseRemove && len(stk.Branches) > 0 {
+		fmt.Println()
+		fmt.Println(ui.IconArrow + " Updating PR descriptions...")
+		if err := UpdateAllPRDescriptions(stk, provider); err != nil {
+			ui.Warning("Failed to update PR descriptions: %v", err)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// pr merge - Merge a PR and update the rest of the stack
+// ============================================================================
+
+var prMergeCmd = &cobra.Command{
+	Use:   "merge [branch]",
+	Short: "Merge a PR and update the stack",
+	Long: `Merge the pull request for a branch (the current branch by default).
+
+After merging:
+  - The next branch's PR is retargeted to the merged branch's parent
+  - The merged branch is removed from the stack (unless --remove=false)
+  - The remaining PR descriptions are updated
+
+Use --remove=false to keep the merged branch in the stack for reference.
+It is then shown as merged in the stack section of the other PRs.
+
+Use --restack to rebase the remaining branches onto the merged branch's
+parent right away, instead of waiting for the next 'stk sync'. Only the
+commits above the merged branch are replayed, so squash and rebase merges
+don't conflict with their own rewritten commits. Without --restack, the
+merged branch's tip is recorded on its child, and the next 'stk sync'
+replays only the child's own commits the same way. 'stk sync' does this
+too for PRs merged outside stk, as long as the merged branch still exists
+locally.
+
+Use --auto to merge once the PR's required checks pass (GitHub auto-merge,
+GitLab "merge when pipeline succeeds"). Like a queued PR, it isn't merged
+yet when the command returns, so the stack is left as is.
+
+Use --queue to add the PR to GitHub's merge queue instead of merging it
+directly. PRs whose base branch requires a merge queue are enqueued
+automatically. A queued PR isn't merged yet, so the stack is left as is;
+run 'stk sync' once the queue has landed it.
+
+Merging a PR while the branches below it are still unmerged lands it in
+its parent branch rather than the base, and leaves the stack broken. This
+is refused unless --force is given.
+
+Examples:
+  stk pr merge                  # Merge current branch's PR
+  stk pr merge feature-auth     # Merge a specific branch's PR
+  stk pr merge --method squash  # Squash-merge
+  stk pr merge --remove=false   # Keep the merged branch in the stack
+  stk pr merge --restack        # Rebase the rest of the stack immediately
+  stk pr merge --queue          # Add to the merge queue
+  stk pr merge --auto           # Merge when checks pass
+  stk pr merge --force          # Merge even if PRs below are unmerged`,
+	RunE: runPRMerge,
+}
+
+var (
+	prMergeMethod       string
+	prMergeDeleteBranch bool
+	prMergeRemove       bool
+	prMergeRestack      bool
+	prMergeQueue        bool
+	prMergeAuto         bool
+	prMergeForce        bool
+)
+
+func init() {
+	prMergeCmd.Flags().StringVar(&prMergeMethod, "method", "merge", "merge method (merge, squash, rebase)")
+	prMergeCmd.Flags().BoolVar(&prMergeDeleteBranch, "delete-branch", false, "delete the remote branch after merging")
+	prMergeCmd.Flags().BoolVar(&prMergeRemove, "remove", true, "remove the merged branch from the stack")
+	prMergeCmd.Flags().BoolVar(&prMergeRestack, "restack", false, "rebase the remaining branches after merging")
+	prMergeCmd.Flags().BoolVar(&prMergeQueue, "queue", false, "add the PR to the merge queue instead of merging directly")
+	prMergeCmd.Flags().BoolVar(&prMergeAuto, "auto", false, "merge once required checks pass")
+	prMergeCmd.Flags().BoolVarP(&prMergeForce, "force", "f", false, "merge even if the PRs below it aren't merged")
+	prMergeCmd.MarkFlagsMutuallyExclusive("queue", "auto")
+	prCmd.AddCommand(prMergeCmd)
+}
+
+func runPRMerge(cmd *cobra.Command, args []string) error {
+	stk := RequireStack()
+	if prMergeRestack {
+		RequireCleanTree()
+	}
+
+	var branchName string
+	if len(args) > 0 {
+		branchName = args[0]
+	} else {
+		var err error
+		branchName, err = Git().CurrentBranch()
+		if err != nil {
+			return err
+		}
+	}
+
+	idx := stk.FindBranch(branchName)
+	if idx < 0 {
+		return fmt.Errorf("branch %q not in stack", branchName)
+	}
+
+	branch := stk.Branches[idx]
+	if branch.PR == nil || branch.PR.Number == 0 {
+		return fmt.Errorf("no PR found for %s; run 'stk pr create' first", branchName)
+	}
+	if branch.PR.State == "merged" {
+		return fmt.Errorf("PR #%d is already merged", branch.PR.Number)
+	}
+
+	provider, err := getProvider()
+	if err != nil {
+		return err
+	}
+
+	if below := unmergedBelow(stk, idx, provider); len(below) > 0 {
+		if !prMergeForce {
+			return fmt.Errorf("%s has unmerged branches below it (%s); merge those first or use --force",
+				branchName, strings.Join(below, ", "))
+		}
+		ui.Warning("Merging %s before %s", branchName, strings.Join(below, ", "))
+	}
+
+	opts := pr.MergeOptions{
+		Method:       prMergeMethod,
+		DeleteBranch: prMergeDeleteBranch,
+		Queue:        prMergeQueue,
+		Auto:         prMergeAuto,
+	}
+
+	fmt.Printf("%s Merging PR #%d (%s)...\n", ui.IconArrow, branch.PR.Number, branchName)
+	mergeSHA, err := provider.Merge(branch.PR.Number, opts)
+	if errors.Is(err, pr.ErrMergeQueue) && !opts.Auto {
+		fmt.Printf("  %s requires a merge queue; enqueuing instead\n", stk.GetPRBase(branchName))
+		opts.Queue = true
+		mergeSHA, err = provider.Merge(branch.PR.Number, opts)
+	}
+	if err != nil {
+		if errors.Is(err, pr.ErrConflict) {
+			return fmt.Errorf("PR #%d has conflicts; run 'stk sync' and resolve them before merging", branch.PR.Number)
+		}
+		return fmt.Errorf("failed to merge PR #%d: %w", branch.PR.Number, err)
+	}
+	forgetCachedPR(branch.PR.Number)
+
+	// The queue merges the PR later; the stack is updated by 'stk sync'
+	// once it has landed
+	if opts.Queue {
+		ui.Success("Added PR #%d to the merge queue", branch.PR.Number)
+		fmt.Println(ui.Dim + "It won't show as merged until the queue lands it; run 'stk sync' afterwards" + ui.Reset)
+		return nil
+	}
+	if opts.Auto {
+		ui.Success("Enabled auto-merge for PR #%d", branch.PR.Number)
+		fmt.Println(ui.Dim + "It will merge when its checks pass; run 'stk sync' afterwards" + ui.Reset)
+		return nil
+	}
+	if mergeSHA != "" {
+		ui.Success("Merged PR #%d as %.7s", branch.PR.Number, mergeSHA)
+	} else {
+		ui.Success("Merged PR #%d", branch.PR.Number)
+	}
+
+	children := recordMerge(stk, provider, branchName, mergeSHA, prMergeRemove)
+
+	if prMergeRestack && len(children) > 0 {
+		fmt.Println()
+		if err := restackAfterMerge(branchName, children[0], mergeSHA); err != nil {
+			ui.Warning("%v; run 'stk sync' to rebase the stack", err)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(ui.IconArrow + " Updating PR descriptions...")
+	if err := UpdateAllPRDescriptions(stk, provider); err != nil {
+		ui.Warning("Failed to update PR descriptions: %v", err)
+	}
+
+	fmt.Println()
+	ui.Success("Merge complete")
+	return nil
+}
+
+// recordMerge marks the PR of a merged branch as merged, retargets its
+// children's PRs and, with remove set, removes the branch from the stack.
+// It returns the branch's children from before the removal.
+func recordMerge(stk *stack.Stack, provider pr.Provider, branchName, mergeSHA string, remove bool) []string {
+	merged := *stk.Branches[stk.FindBranch(branchName)].PR
+	merged.State = "merged"
+	merged.MergeSHA = mergeSHA
+	_ = Manager().UpdatePR(stk, branchName, &merged)
+
+	// Retarget the child, whose parent changed regardless of whether the
+	// merged branch stays in the stack
+	children := stk.GetChildren(branchName)
+	for _, childName := range children {
+		child := stk.Branches[stk.FindBranch(childName)]
+		if child.PR == nil || child.PR.Number == 0 {
+			continue
+		}
+		newBase := stk.GetPRBase(childName)
+		fmt.Printf("  Retargeting PR #%d to %s\n", child.PR.Number, newBase)
+		if err := provider.Retarget(child.PR.Number, newBase); err != nil {
+			ui.Warning("Failed to retarget PR #%d: %v", child.PR.Number, err)
+		} else {
+			recordPRBase(stk, childName, newBase)
+		}
+	}
+
+	if remove {
+		recordMergedParentTip(stk, branchName)
+		if err := Manager().RemoveBranch(stk, branchName); err != nil {
+			ui.Warning("Failed to remove %s from stack: %v", branchName, err)
+		} else {
+			fmt.Printf("  Removed %s from stack\n", branchName)
+		}
+	}
+	return children
+}
+
+// unmergedBelow lists the branches below stk.Branches[idx] whose PRs aren't
+// merged, with the PR number if there is one. PRs not recorded as merged
+// are checked against the provider, since they may have been merged
+// outside stk.
+func unmergedBelow(stk *stack.Stack, idx int, provider pr.Provider) []string {
+	cache := newPRCache(false)
+	defer cache.save()
+
+	var below []string
+	for _, b := range stk.Branches[:idx] {
+		if b.PR == nil || b.PR.Number == 0 {
+			below = append(below, b.Name+" (no PR)")
+			continue
+		}
+		if b.PR.State == "merged" {
+			continue
+		}
+		if remotePR, err := cache.get(provider, b.PR.Number); err == nil && remotePR != nil && remotePR.State == "merged" {
+			continue
+		}
+		below = append(below, fmt.Sprintf("%s (#%d)", b.Name, b.PR.Number))
+	}
+	return below
+}
+
+// restackAfterMerge rebases the merged branch's child, and every branch
+// above it, onto the child's new parent. Each branch is rebased with --onto
+// from its parent's old tip, so only its own commits are replayed. If
+// mergeSHA is known, the child is rebased directly onto the merge commit.
+func restackAfterMerge(merged, child, mergeSHA string) error {
+	stk, err := Manager().Current()
+	if err != nil {
+		return err
+	}
+	start := stk.FindBranch(child)
+	if start < 0 {
+		return fmt.Errorf("branch %q not in stack", child)
+	}
+
+	// The merged branch still exists locally; its tip marks where the
+	// child's own commits begin
+	mergedSHA, err := Git().SHA(merged)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", merged, err)
+	}
+
+	// The merge landed on the remote, so rebase onto that rather than a
+	// possibly stale local base
+	onto := stk.GetPRBase(child)
+	if onto == stk.Base {
+		if err := Git().Fetch("origin"); err != nil {
+			ui.Warning("Failed to fetch: %v", err)
+		} else if mergeSHA != "" && Git().CommitExists(mergeSHA) {
+			onto = mergeSHA
+		} else if Git().RemoteBranchExists("origin", stk.Base) {
+			onto = "origin/" + stk.Base
+		}
+	}
+
+	return restackBranches(stk, restackOptions{
+		start:    start,
+		onto:     onto,
+		upstream: mergedSHA,
+	})
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stefanaki/stk/internal/git"
+	"github.com/stefanaki/stk/internal/pr"
+	"github.com/stefanaki/stk/internal/stack"
+)
+
+// fakeProvider records the calls the merge bookkeeping makes. Methods it
+// doesn't override panic through the nil embedded Provider.
+type fakeProvider struct {
+	pr.Provider
+
+	prs       map[int]*pr.PR
+	retargets map[int]string
+	bodies    map[int]string
+	gets      map[int]int
+}
+
+func newFakeProvider(prs ...*pr.PR) *fakeProvider {
+	f := &fakeProvider{
+		prs:       make(map[int]*pr.PR),
+		retargets: make(map[int]string),
+		bodies:    make(map[int]string),
+		gets:      make(map[int]int),
+	}
+	for _, p := range prs {
+		f.prs[p.Number] = p
+	}
+	return f
+}
+
+func (f *fakeProvider) Get(number int) (*pr.PR, error) {
+	f.gets[number]++
+	p, ok := f.prs[number]
+	if !ok {
+		return nil, pr.ErrNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (f *fakeProvider) Retarget(number int, newBase string) error {
+	f.retargets[number] = newBase
+	f.prs[number].Base = newBase
+	return nil
+}
+
+func (f *fakeProvider) Update(number int, opts pr.UpdateOptions) error {
+	if opts.Body != nil {
+		f.bodies[number] = *opts.Body
+		f.prs[number].Body = *opts.Body
+	}
+	return nil
+}
+
+// setupStack points the shared manager at a temporary directory and creates
+// a stack on main with the given branches, each with an open PR numbered
+// from 1.
+func setupStack(t *testing.T, branches ...string) *stack.Stack {
+	t.Helper()
+	manager = stack.NewManager(t.TempDir())
+	cfg = nil
+
+	stk, err := manager.Create("feature", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range branches {
+		if err := manager.AppendBranch(stk, name); err != nil {
+			t.Fatal(err)
+		}
+		if err := manager.UpdatePR(stk, name, &stack.PR{Number: i + 1, State: "open"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return stk
+}
+
+func TestRecordMergeKeepsBranchWithoutRemove(t *testing.T) {
+	stk := setupStack(t, "models", "api")
+	provider := newFakeProvider(
+		&pr.PR{Number: 1, State: "merged", Head: "models", Base: "main"},
+		&pr.PR{Number: 2, State: "open", Head: "api", Base: "models"},
+	)
+
+	children := recordMerge(stk, provider, "models", "abc123", false)
+	if len(children) != 1 || children[0] != "api" {
+		t.Fatalf("children = %v, want [api]", children)
+	}
+
+	stk, err := manager.Current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx := stk.FindBranch("models")
+	if idx < 0 {
+		t.Fatal("models was removed from the stack")
+	}
+	if got := stk.Branches[idx].PR; got.State != "merged" || got.MergeSHA != "abc123" {
+		t.Errorf("models PR = %+v, want merged at abc123", got)
+	}
+
+	// The child's parent changed even though the merged branch stayed
+	if base := provider.retargets[2]; base != "main" {
+		t.Errorf("PR #2 retargeted to %q, want main", base)
+	}
+	if base := stk.Branches[stk.FindBranch("api")].PR.Base; base != "main" {
+		t.Errorf("recorded base of PR #2 = %q, want main", base)
+	}
+
+	if err := UpdateAllPRDescriptions(stk, provider); err != nil {
+		t.Fatal(err)
+	}
+	if body := provider.bodies[2]; !strings.Contains(body, "`models`") || !strings.Contains(body, "✅ Merged") {
+		t.Errorf("stack section of PR #2 doesn't show models as merged:\n%s", body)
+	}
+}
+
+// initRepo points the shared git instance at a new repository in a
+// temporary directory, with one commit on main, and makes it the working
+// directory.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("GIT_AUTHOR_NAME", "stk")
+	t.Setenv("GIT_AUTHOR_EMAIL", "stk@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "stk")
+	t.Setenv("GIT_COMMITTER_EMAIL", "stk@example.com")
+
+	g = git.New()
+	runGit(t, "init", "-q", "-b", "main")
+	runGit(t, "commit", "-q", "--allow-empty", "-m", "initial")
+	return dir
+}
+
+// runGit runs a git command in the working directory, failing the test if
+// it fails.
+func runGit(t *testing.T, args ...string) {
+	t.Helper()
+	if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func TestPRTitleTruncatesLongCommitSubject(t *testing.T) {
+	initRepo(t)
+	subject := strings.Repeat("refactor the session store ", 20)
+	runGit(t, "checkout", "-q", "-b", "session")
+	runGit(t, "commit", "-q", "--allow-empty", "-m", subject)
+	runGit(t, "commit", "-q", "--allow-empty", "-m", "follow-up")
+
+	title := prTitle("", "session", "main", true, 64)
+	if n := len([]rune(title)); n != 64 {
+		t.Errorf("title has %d characters, want 64: %q", n, title)
+	}
+	if !strings.HasPrefix(title, "refactor the session store") || !strings.HasSuffix(title, "…") {
+		t.Errorf("title = %q, want the first commit's subject cut with an ellipsis", title)
+	}
+
+	// Without --fill the branch name is used
+	if got := prTitle("", "session", "main", false, 64); got != "session" {
+		t.Errorf("title without --fill = %q, want session", got)
+	}
+}
+
+func TestPRTitleKeepsExplicitTitle(t *testing.T) {
+	explicit := strings.Repeat("x", 300)
+	if got := prTitle(explicit, "session", "main", true, 64); got != explicit {
+		t.Errorf("explicit title was changed to %q", got)
+	}
+}
+
+func TestUpdateAllPRDescriptionsReadsEachPROnce(t *testing.T) {
+	stk := setupStack(t, "models", "api")
+	provider := newFakeProvider(
+		&pr.PR{Number: 1, State: "open", Head: "models", Base: "main", Body: pr.ComposeBody("Adds models", "")},
+		&pr.PR{Number: 2, State: "open", Head: "api", Base: "models", Body: pr.ComposeBody("Adds the API", "")},
+	)
+
+	if err := UpdateAllPRDescriptions(stk, provider); err != nil {
+		t.Fatal(err)
+	}
+	for number, gets := range provider.gets {
+		if gets != 1 {
+			t.Errorf("PR #%d was read %d times, want once", number, gets)
+		}
+	}
+	if body := provider.bodies[2]; !strings.HasPrefix(body, "Adds the API") {
+		t.Errorf("PR #2 lost its description:\n%s", body)
+	}
+}
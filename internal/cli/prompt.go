@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stefanaki/stk/internal/git"
+	"github.com/stefanaki/stk/internal/stack"
+)
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print the stack position for a shell prompt",
+	Long: `Print a short string for embedding in a shell prompt: the current
+stack's name and the current branch's position in it, e.g. "my-feature:2/5".
+On the stack's base the position is 0.
+
+Only local state is read, so it's fast and never touches the network.
+Nothing is printed, and the exit status is 0, outside a git repository,
+without a current stack, or on a branch that isn't part of it.
+
+Examples:
+  # bash
+  PS1='$(stk prompt) \$ '
+  # zsh
+  setopt PROMPT_SUBST; PROMPT='$(stk prompt) %# '`,
+	Args: cobra.NoArgs,
+	Run:  runPrompt,
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+}
+
+func runPrompt(cmd *cobra.Command, args []string) {
+	// Runs without the root pre-run, which would fail loudly outside a repo
+	gp := git.New()
+	if !gp.IsInsideWorkTree() {
+		return
+	}
+	gitDir, err := gp.GitDir()
+	if err != nil {
+		return
+	}
+	stk, err := stack.NewManager(gitDir).Current()
+	if err != nil || stk == nil {
+		return
+	}
+	current, err := gp.CurrentBranch()
+	if err != nil || current == "" {
+		return
+	}
+
+	pos := 0
+	if current != stk.Base {
+		pos = stk.FindBranch(current) + 1
+		if pos == 0 {
+			return
+		}
+	}
+	fmt.Printf("%s:%d/%d", stk.Name, pos, len(stk.Branches))
+}
@@ -7,14 +7,20 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/stefanaki/stk/internal/config"
 	"github.com/stefanaki/stk/internal/git"
 	"github.com/stefanaki/stk/internal/stack"
+	"github.com/stefanaki/stk/internal/ui"
 )
 
 var (
 	// Shared instances
 	g       *git.Git
 	manager *stack.Manager
+	cfg     *config.Config
+
+	// noPager disables paging of long output
+	noPager bool
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -36,13 +42,20 @@ Example workflow:
   stk sync                         # Fetch, rebase stack onto latest base
   stk submit                       # Push all branches, create/update PRs`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Skip initialization for commands that don't need git
-		if cmd.Name() == "help" || cmd.Name() == "version" || cmd.Name() == "completion" {
+		// Skip initialization for commands that don't need git.
+		// Dynamic completions build their own manager via completionManager.
+		switch cmd.Name() {
+		case "help", "version", "completion", "gen-docs", "prompt", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
 			return nil
 		}
 
-		// Initialize git wrapper
+		// Initialize git wrapper; the working tree only changes through
+		// our own git calls for the rest of the command
 		g = git.New()
+		g.CacheStatus = true
+		if err := g.CheckAvailable(); err != nil {
+			return err
+		}
 
 		// Check if we're in a git repository
 		if !g.IsInsideWorkTree() {
@@ -56,10 +69,42 @@ Example workflow:
 		}
 
 		manager = stack.NewManager(gitDir)
+
+		// config init may be replacing a malformed file, so it doesn't load it
+		if cmd == configInitCmd {
+			return nil
+		}
+
+		// Load repository config
+		root, err := g.RepoRoot()
+		if err != nil {
+			return fmt.Errorf("failed to find repository root: %w", err)
+		}
+		cfg, err = config.Load(root)
+		if err != nil {
+			return err
+		}
+		if cfg.IgnoredToken {
+			ui.Warning("Ignoring token in %s, which is usually committed; remove it and run 'git config %s <token>' instead",
+				config.FileName, config.TokenGitConfig)
+		}
+		manager.AllowSharedBranches = cfg.AllowSharedBranches
+
+		// The environment overrides the configured git binary
+		if cfg.GitBinary != "" && os.Getenv(git.BinaryEnv) == "" {
+			g.Binary = cfg.GitBinary
+			if err := g.CheckAvailable(); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
 }
 
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "don't page long output")
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
 	return rootCmd.Execute()
@@ -75,6 +120,20 @@ func Manager() *stack.Manager {
 	return manager
 }
 
+// Config returns the repository config.
+func Config() *config.Config {
+	return cfg
+}
+
+// page prints long output through the pager unless --no-pager was given.
+func page(content string) {
+	if noPager {
+		fmt.Print(content)
+		return
+	}
+	ui.Page(content)
+}
+
 // RequireStack loads the current stack or exits with an error.
 func RequireStack() *stack.Stack {
 	s, err := manager.Current()
@@ -86,9 +145,21 @@ func RequireStack() *stack.Stack {
 }
 
 // RequireCleanTree ensures the working tree is clean or exits.
+// An in-progress rebase counts as unclean.
+// With strict_submodules set in the config, dirty submodules also count.
 func RequireCleanTree() {
+	if hint := rebaseInProgressHint(); hint != "" {
+		fmt.Fprintln(os.Stderr, "Error:", hint)
+		os.Exit(1)
+	}
 	if err := g.EnsureClean(); err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
 		os.Exit(1)
 	}
+	if cfg != nil && cfg.StrictSubmodules {
+		if err := g.EnsureCleanSubmodules(); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
 }
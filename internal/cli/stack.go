@@ -1,10 +1,17 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/stefanaki/stk/internal/git"
+	"github.com/stefanaki/stk/internal/stack"
 	"github.com/stefanaki/stk/internal/ui"
 )
 
@@ -18,15 +25,47 @@ Shows:
   - All branches in the stack
   - Current branch indicator
   - Commit SHAs (with --sha flag)
-  - PR status (if available)`,
+  - PR status (if available)
+  - Branches missing commits from their parent, which need a restack
+    ('stk sync --no-fetch'); computed locally
+  - Total size of the stack's diff against its base (with --stat flag)
+  - Open PRs for branches that stk isn't tracking (with --refresh flag,
+    which queries the PR provider)
+
+Use --pr-title to show each PR's title (as last fetched) after its
+number, shortened to fit the terminal.
+
+Use --legend to print a key to the icons and colors.
+
+Use --short (-s) for a compact listing meant for shell prompts and
+status bars: one line per branch with its position, a "*" on the current
+branch, its name, PR number and PR state.
+
+Output taller than the terminal is shown in a pager ($STK_PAGER, then
+$PAGER, then 'less -R'); use --no-pager to print it directly.`,
 	Aliases: []string{"st"},
 	RunE:    runStatus,
 }
 
-var statusShowSHA bool
+var (
+	statusShowSHA  bool
+	statusShowStat bool
+	statusRefresh  bool
+	statusLegend   bool
+	statusPRTitle  bool
+	statusShort    bool
+)
 
 func init() {
 	statusCmd.Flags().BoolVar(&statusShowSHA, "sha", false, "show commit SHAs")
+	statusCmd.Flags().BoolVar(&statusShowStat, "stat", false, "show the total diff size of the stack")
+	statusCmd.Flags().BoolVar(&statusRefresh, "refresh", false, "look up open PRs that aren't tracked in the stack")
+	statusCmd.Flags().BoolVar(&statusPRTitle, "pr-title", false, "show PR titles")
+	statusCmd.Flags().BoolVar(&statusLegend, "legend", false, "explain the icons and colors")
+	statusCmd.Flags().BoolVarP(&statusShort, "short", "s", false, "print one compact line per branch")
+	statusCmd.MarkFlagsMutuallyExclusive("short", "legend")
+	statusCmd.MarkFlagsMutuallyExclusive("short", "pr-title")
+	statusCmd.MarkFlagsMutuallyExclusive("short", "stat")
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -35,40 +74,198 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	current, _ := Git().CurrentBranch()
 
+	if statusShort {
+		fmt.Print(ui.RenderShort(stack, current))
+		return nil
+	}
+
 	opts := ui.TreeOptions{
 		ShowSHA:       statusShowSHA,
 		ShowPR:        true,
+		ShowPRTitle:   statusPRTitle,
 		CurrentBranch: current,
+		BranchPrefix:  Config().BranchPrefix,
 		GetSHA: func(name string) string {
 			sha, _ := Git().ShortSHA(name)
 			return sha
 		},
+		BehindParent: func(name string) int {
+			n, err := Git().CommitCount(name, stack.GetParent(name))
+			if err != nil {
+				return 0
+			}
+			return n
+		},
+	}
+
+	if statusPRTitle && isTerminal(os.Stdout) {
+		opts.Width = terminalWidth()
+	}
+
+	// Highlight branches that moved while a snapshot is pending
+	if stack.Snapshot != nil {
+		opts.IsMoved = func(name string) bool {
+			snapSHA, ok := stack.Snapshot.Refs[name]
+			if !ok {
+				return false
+			}
+			sha, err := Git().SHA(name)
+			return err == nil && sha != snapSHA
+		}
 	}
 
-	fmt.Print(ui.RenderStatus(stack, opts))
+	// Collect the output so long stacks can be paged
+	var out strings.Builder
+	if hint := rebaseInProgressHint(); hint != "" {
+		fmt.Fprintln(&out, ui.Yellow+"Note: "+hint+ui.Reset)
+		fmt.Fprintln(&out)
+	}
+
+	if line := baseSyncLine(stack.Base); line != "" {
+		fmt.Fprintln(&out, line)
+		fmt.Fprintln(&out)
+	}
+
+	out.WriteString(ui.RenderStatus(stack, opts))
+
+	if len(stack.Branches) == 0 {
+		fmt.Fprintln(&out)
+		fmt.Fprintf(&out, "%sNo branches yet. Check out %s and run 'stk branch <name>' to add the first one.%s\n", ui.Dim, stack.Base, ui.Reset)
+	}
+
+	if statusShowStat && len(stack.Branches) > 0 {
+		tip := stack.Branches[len(stack.Branches)-1].Name
+		if stat, err := Git().DiffShortStat(stack.Base, tip); err == nil && stat != "" {
+			fmt.Fprintln(&out)
+			fmt.Fprintln(&out, ui.Dim+"Total: "+stat+ui.Reset)
+		}
+	}
+
+	if statusLegend {
+		fmt.Fprintln(&out)
+		out.WriteString(ui.RenderLegend())
+	}
+	page(out.String())
+
+	// Provider lookups report as they go, so they aren't paged
+	if statusRefresh {
+		printUntrackedPRs(stack)
+	}
 	return nil
 }
 
+// printUntrackedPRs hints at open PRs for branches that have no PR in the
+// stack metadata, e.g. ones created outside stk.
+func printUntrackedPRs(stk *stack.Stack) {
+	provider, err := getProvider()
+	if err != nil {
+		ui.Warning("Failed to get PR provider: %v", err)
+		return
+	}
+
+	var hints []string
+	for _, branch := range stk.Branches {
+		if branch.PR != nil && branch.PR.Number > 0 {
+			continue
+		}
+		remotePR, err := provider.GetByBranch(branch.Name)
+		if err != nil || remotePR == nil {
+			continue
+		}
+		hints = append(hints, fmt.Sprintf("%s: untracked PR #%d exists; run 'stk pr create %s' to track it",
+			branch.Name, remotePR.Number, branch.Name))
+	}
+	if len(hints) == 0 {
+		return
+	}
+
+	fmt.Println()
+	for _, hint := range hints {
+		fmt.Println(ui.Yellow + hint + ui.Reset)
+	}
+}
+
+// baseSyncLine describes whether the base branch is in sync with origin,
+// or returns an empty string when there's no remote base to compare.
+func baseSyncLine(base string) string {
+	ahead, behind, ok := baseSyncState(base)
+	if !ok {
+		return ""
+	}
+
+	switch {
+	case ahead == 0 && behind == 0:
+		return ui.Green + fmt.Sprintf("Base %s is in sync with origin", base) + ui.Reset
+	case ahead == 0:
+		return ui.Yellow + fmt.Sprintf("Base %s is %d commit(s) behind origin; run 'stk sync'", base, behind) + ui.Reset
+	case behind == 0:
+		return ui.Yellow + fmt.Sprintf("Base %s is %d commit(s) ahead of origin", base, ahead) + ui.Reset
+	default:
+		return ui.Red + fmt.Sprintf("Base %s has diverged from origin (%d ahead, %d behind)", base, ahead, behind) + ui.Reset
+	}
+}
+
 var listCmd = &cobra.Command{
-	Use:     "list",
-	Short:   "List all stacks",
-	Long:    `List all stacks in the repository.`,
+	Use:   "list",
+	Short: "List all stacks",
+	Long: `List all stacks in the repository.
+
+Use --format for output meant for scripts: "plain" prints one stack name
+per line with no current marker, "json" prints an array of objects with
+the stack name and whether it is the current stack.
+
+Examples:
+  stk list                 # Decorated list
+  stk list --format plain  # Names only, e.g. for shell loops
+  stk list --format json   # [{"name": "...", "current": true}, ...]`,
 	Aliases: []string{"ls"},
 	RunE:    runList,
 }
 
+var listFormat string
+
 func init() {
+	listCmd.Flags().StringVar(&listFormat, "format", "", "output format for scripts (plain or json)")
 	rootCmd.AddCommand(listCmd)
 }
 
+// listEntry is a stack in 'stk list --format json' output.
+type listEntry struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
 func runList(cmd *cobra.Command, args []string) error {
+	switch listFormat {
+	case "", "plain", "json":
+	default:
+		return fmt.Errorf("invalid --format %q (expected plain or json)", listFormat)
+	}
+
 	stacks, err := Manager().List()
 	if err != nil {
 		return err
 	}
 
 	current, _ := Manager().Storage().GetCurrent()
-	fmt.Print(ui.RenderList(stacks, current))
+	switch listFormat {
+	case "plain":
+		for _, name := range stacks {
+			fmt.Println(name)
+		}
+	case "json":
+		entries := make([]listEntry, 0, len(stacks))
+		for _, name := range stacks {
+			entries = append(entries, listEntry{Name: name, Current: name == current})
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	default:
+		fmt.Print(ui.RenderList(stacks, current))
+	}
 	return nil
 }
 
@@ -79,9 +276,10 @@ var switchCmd = &cobra.Command{
 
 This only changes which stack stk commands operate on.
 It does not checkout any branches.`,
-	Aliases: []string{"sw"},
-	Args:    cobra.ExactArgs(1),
-	RunE:    runSwitch,
+	Aliases:           []string{"sw"},
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeStackNames,
+	RunE:              runSwitch,
 }
 
 func init() {
@@ -110,9 +308,10 @@ var deleteCmd = &cobra.Command{
 
 This removes the stack metadata but does NOT delete the git branches.
 Use 'git branch -d <branch>' to delete branches manually.`,
-	Aliases: []string{"rm"},
-	Args:    cobra.ExactArgs(1),
-	RunE:    runDelete,
+	Aliases:           []string{"rm"},
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeStackNames,
+	RunE:              runDelete,
 }
 
 var deleteForce bool
@@ -139,11 +338,12 @@ func runDelete(cmd *cobra.Command, args []string) error {
 }
 
 var renameCmd = &cobra.Command{
-	Use:   "rename <old-name> <new-name>",
-	Short: "Rename a stack",
-	Long:  `Rename a stack to a new name.`,
-	Args:  cobra.ExactArgs(2),
-	RunE:  runRename,
+	Use:               "rename <old-name> <new-name>",
+	Short:             "Rename a stack",
+	Long:              `Rename a stack to a new name.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeStackNames,
+	RunE:              runRename,
 }
 
 func init() {
@@ -170,7 +370,18 @@ var doctorCmd = &cobra.Command{
 Validates:
   - All branches in the stack exist
   - Base branch exists
-  - No duplicate branches`,
+  - No duplicate branches
+  - Base overrides point at existing branches
+  - PRs target their parent in the stack (warning; checked against the
+    base recorded when stk last saw each PR, without network access)
+  - No stale rollback snapshot (warning)
+  - Frozen branches contain their parent (warning)
+  - The repository is ready for stack operations: HEAD is on a branch, no
+    rebase, merge, cherry-pick or revert is half done, and the working
+    tree is clean (warnings, each with the command to recover)
+
+Exits non-zero only when errors are found; warnings are printed
+but don't fail, so 'stk doctor' can gate CI on real problems.`,
 	RunE: runDoctor,
 }
 
@@ -179,33 +390,135 @@ func init() {
 }
 
 func runDoctor(cmd *cobra.Command, args []string) error {
-	stack := RequireStack()
+	stk := RequireStack()
 
-	errors := Manager().Validate(stack, func(name string) bool {
+	issues := Manager().Validate(stk, func(name string) bool {
 		return Git().BranchExists(name)
 	})
 
-	if len(errors) == 0 {
-		ui.Success("Stack %q is healthy", stack.Name)
+	// stk won't rebase frozen branches, so point out ones left behind
+	for _, b := range stk.Branches {
+		parent := stk.GetParent(b.Name)
+		if !b.Frozen || !Git().BranchExists(b.Name) || !Git().BranchExists(parent) {
+			continue
+		}
+		if !Git().IsAncestor(parent, b.Name) {
+			issues = append(issues, stack.ValidationError{
+				Branch:   b.Name,
+				Message:  fmt.Sprintf("frozen branch is behind %s; update it by hand or 'stk unfreeze' it", parent),
+				Severity: stack.SeverityWarning,
+			})
+		}
+	}
+
+	issues = append(issues, repoStateIssues()...)
+
+	if len(issues) == 0 {
+		ui.Success("Stack %q is healthy", stk.Name)
 		return nil
 	}
 
-	ui.Error("Found %d issue(s):", len(errors))
-	for _, e := range errors {
-		fmt.Printf("  %s: %s\n", e.Branch, e.Message)
+	var errorCount int
+	for _, e := range issues {
+		if e.IsError() {
+			errorCount++
+		}
+	}
+
+	if errorCount > 0 {
+		ui.Error("Found %d error(s):", errorCount)
+		for _, e := range issues {
+			if e.IsError() {
+				fmt.Printf("  %s: %s\n", e.Branch, e.Message)
+			}
+		}
+	}
+
+	if warningCount := len(issues) - errorCount; warningCount > 0 {
+		ui.Warning("Found %d warning(s):", warningCount)
+		for _, e := range issues {
+			if !e.IsError() {
+				fmt.Printf("  %s: %s\n", e.Branch, e.Message)
+			}
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("stack has validation errors")
+	}
+	return nil
+}
+
+// repoStateIssues reports git states that stop stack operations, such as
+// a detached HEAD or a half-finished merge.
+func repoStateIssues() []stack.ValidationError {
+	var issues []stack.ValidationError
+	warn := func(subject, format string, args ...interface{}) {
+		issues = append(issues, stack.ValidationError{
+			Branch:   subject,
+			Message:  fmt.Sprintf(format, args...),
+			Severity: stack.SeverityWarning,
+		})
 	}
 
-	return fmt.Errorf("stack has validation errors")
+	// A rebase detaches HEAD, so only report one of the two
+	if hint := rebaseInProgressHint(); hint != "" {
+		warn("HEAD", "%s", hint)
+	} else if current, err := Git().CurrentBranch(); err == nil && current == "" {
+		warn("HEAD", "HEAD is detached; check out a stack branch with 'git checkout <branch>' or 'stk goto <n>'")
+	}
+
+	switch op := Git().OperationInProgress(); op {
+	case "merge":
+		warn("HEAD", "a merge is in progress; finish it with 'git commit' or undo it with 'git merge --abort'")
+	case "cherry-pick", "revert":
+		warn("HEAD", "a %s is in progress; finish it with 'git %s --continue' or undo it with 'git %s --abort'", op, op, op)
+	}
+
+	if clean, err := Git().IsClean(); err == nil && !clean {
+		warn("working tree", "uncommitted changes; commit them or set them aside with 'git stash'")
+	}
+	if Config().StrictSubmodules {
+		if dirty, err := Git().DirtySubmodules(); err == nil && len(dirty) > 0 {
+			warn("working tree", "submodule(s) have uncommitted changes: %s; commit or reset them", strings.Join(dirty, ", "))
+		}
+	}
+	return issues
 }
 
 var logCmd = &cobra.Command{
 	Use:   "log",
 	Short: "Show stack as a tree",
-	Long:  `Display the stack as a visual tree with branch relationships.`,
-	RunE:  runLog,
+	Long: `Display the stack as a visual tree with branch relationships.
+
+Use --pr-only to list just the stack's PRs (number, state, title and URL),
+e.g. for sharing. Add --markdown to print a markdown list ready to paste.
+Only locally recorded PR info is used; run 'stk pr status --refresh' first
+for up-to-date states.
+
+Use --dot to print the branch graph in Graphviz DOT format, with each
+branch's PR number and state, e.g. to render it with 'dot -Tpng'.
+
+Examples:
+  stk log                        # Tree view
+  stk log --pr-only              # PR list
+  stk log --pr-only --markdown   # PR list as markdown
+  stk log --dot | dot -Tpng -o stack.png`,
+	RunE: runLog,
 }
 
+var (
+	logPROnly   bool
+	logMarkdown bool
+	logDot      bool
+)
+
 func init() {
+	logCmd.Flags().BoolVar(&logPROnly, "pr-only", false, "list only the stack's PRs")
+	logCmd.Flags().BoolVar(&logMarkdown, "markdown", false, "print the PR list as markdown (implies --pr-only)")
+	logCmd.Flags().BoolVar(&logDot, "dot", false, "print the branch graph in Graphviz DOT format")
+	logCmd.MarkFlagsMutuallyExclusive("dot", "pr-only")
+	logCmd.MarkFlagsMutuallyExclusive("dot", "markdown")
 	rootCmd.AddCommand(logCmd)
 }
 
@@ -213,6 +526,15 @@ func runLog(cmd *cobra.Command, args []string) error {
 	stack := RequireStack()
 	current, _ := Git().CurrentBranch()
 
+	if logPROnly || logMarkdown {
+		printPRList(stack, logMarkdown)
+		return nil
+	}
+	if logDot {
+		fmt.Print(ui.RenderDOT(stack, current))
+		return nil
+	}
+
 	opts := ui.TreeOptions{
 		ShowSHA:       true,
 		ShowPR:        true,
@@ -223,6 +545,113 @@ func runLog(cmd *cobra.Command, args []string) error {
 		},
 	}
 
-	fmt.Print(ui.RenderTree(stack, opts))
+	page(ui.RenderTree(stack, opts))
+	return nil
+}
+
+// printPRList prints each branch's PR, either for the terminal or as a
+// markdown list. Branches without a PR are skipped.
+func printPRList(stk *stack.Stack, markdown bool) {
+	if markdown {
+		fmt.Printf("**%s** stack:\n\n", stk.Name)
+	}
+
+	count := 0
+	for _, b := range stk.Branches {
+		if b.PR == nil || b.PR.Number == 0 {
+			continue
+		}
+		count++
+
+		title := b.PR.Title
+		if title == "" {
+			title = b.Name
+		}
+
+		if markdown {
+			fmt.Printf("%d. [#%d](%s) %s (`%s`, %s)\n", count, b.PR.Number, b.PR.URL, title, b.Name, b.PR.State)
+			continue
+		}
+		fmt.Printf("%s  %-7s %s %s(%s)%s\n", ui.PRBadge(b.PR.Number, b.PR.State), b.PR.State, title, ui.Dim, b.Name, ui.Reset)
+		if b.PR.URL != "" {
+			fmt.Printf("  %s%s%s\n", ui.Dim, b.PR.URL, ui.Reset)
+		}
+	}
+
+	if count == 0 && !markdown {
+		ui.Info("No PRs in this stack yet; run 'stk submit' to create them")
+	}
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recent activity on stack branches",
+	Long: `Show the reflog of all branches in the stack, newest first.
+
+Use --since to only show recent entries and --limit to cap the output.
+
+Examples:
+  stk history               # Full history
+  stk history --since 24h   # Last day only
+  stk history --limit 20    # Last 20 entries`,
+	RunE: runHistory,
+}
+
+var (
+	historySince string
+	historyLimit int
+)
+
+func init() {
+	historyCmd.Flags().StringVar(&historySince, "since", "", "only show entries newer than this duration (e.g. 24h, 30m)")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 0, "show at most this many entries")
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	stack := RequireStack()
+
+	var cutoff time.Time
+	if historySince != "" {
+		d, err := time.ParseDuration(historySince)
+		if err != nil {
+			return fmt.Errorf("invalid --since duration %q: %w", historySince, err)
+		}
+		cutoff = time.Now().Add(-d)
+	}
+
+	var entries []git.ReflogEntry
+	for _, b := range stack.Branches {
+		branchEntries, err := Git().Reflog(b.Name)
+		if err != nil {
+			continue // Branch may not exist locally
+		}
+		for _, e := range branchEntries {
+			if !cutoff.IsZero() && e.Time.Before(cutoff) {
+				continue
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+	if historyLimit > 0 && len(entries) > historyLimit {
+		entries = entries[:historyLimit]
+	}
+
+	if len(entries) == 0 {
+		ui.DimText("No history found")
+		return nil
+	}
+
+	for _, e := range entries {
+		fmt.Printf("%s %s %-20s %s\n",
+			ui.Dim+e.Time.Format("2006-01-02 15:04")+ui.Reset,
+			ui.CommitSHA(e.SHA),
+			e.Branch,
+			e.Message)
+	}
 	return nil
 }
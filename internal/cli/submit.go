@@ -1,7 +1,10 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 
@@ -19,17 +22,41 @@ This command performs the following steps:
   1. Check if base branch is synced with remote
   2. Push all branches to origin (with --force-with-lease)
   3. Create PRs for branches that don't have one
-  4. Update PR descriptions with current stack info
+  4. Retarget PRs whose base no longer matches the stack order
+  5. Update PR descriptions with current stack info
 
 Use --no-create-prs to skip creating new PRs.
 Use --no-update-prs to skip updating PR descriptions.
 Use --draft to create new PRs as drafts.
+Use --fill to title new PRs after the subject of each branch's first
+commit rather than the branch name.
+Use --reviewer to request reviews on new PRs, on top of the default
+reviewers (see 'stk pr create --help').
+Branches whose PR was merged are left alone. A branch whose PR was closed
+gets a new PR; use --reopen to reopen the closed PR instead.
+Use --jobs (-j) to push several branches, and create several PRs, at once,
+which helps with deep stacks and slow remotes. PRs created together can't
+list each other in their stack sections until the description update in
+step 5.
+Use --open to open newly created PRs in the browser (their URLs are
+printed instead when not in a terminal).
+Use --body-file to supply the description of new PRs from a file ("-"
+reads stdin); the stack section is appended below it.
+Use --no-stack-section to leave the stack section out of this stack's PR
+descriptions from now on (see 'stk pr create --help').
+Use --only or --exclude to submit part of the stack: only the selected
+branches are pushed and have their PRs created, retargeted and updated.
+PRs still target the branch's parent in the stack, so a selected branch
+whose parent isn't on origin yet gets no PR.
 
 Examples:
   stk submit                  # Push and manage all PRs
   stk submit --draft          # Create new PRs as drafts
   stk submit --no-create-prs  # Push only, don't create PRs
-  stk submit --no-update-prs  # Don't update existing PRs`,
+  stk submit --no-update-prs  # Don't update existing PRs
+  stk submit --reopen         # Reopen closed PRs rather than replacing them
+  stk submit --only feature-a,feature-b
+  stk submit --exclude feature-wip`,
 	RunE: runSubmit,
 }
 
@@ -39,28 +66,62 @@ var (
 	submitDraft       bool
 	submitReviewers   []string
 	submitTitle       string
+	submitFill        bool
 	submitForce       bool
+	submitTargetBase  bool
+	submitMaxTitleLen int
+	submitBodyFile    string
+	submitNoSection   bool
+	submitOpen        bool
+	submitJobs        int
+	submitOnly        []string
+	submitExclude     []string
+	submitReopen      bool
 )
 
 func init() {
 	submitCmd.Flags().BoolVar(&submitNoCreatePRs, "no-create-prs", false, "don't create new PRs")
 	submitCmd.Flags().BoolVar(&submitNoUpdatePRs, "no-update-prs", false, "don't update existing PR descriptions")
 	submitCmd.Flags().BoolVar(&submitDraft, "draft", false, "create new PRs as drafts")
+	submitCmd.Flags().BoolVar(&submitReopen, "reopen", false, "reopen closed PRs instead of creating new ones")
+	submitCmd.Flags().BoolVar(&submitOpen, "open", false, "open newly created PRs in the browser")
 	submitCmd.Flags().StringSliceVar(&submitReviewers, "reviewer", nil, "add reviewers to new PRs")
 	submitCmd.Flags().StringVarP(&submitTitle, "title", "t", "", "title for new PRs (uses branch name if not specified)")
+	submitCmd.Flags().BoolVar(&submitFill, "fill", false, "title new PRs with the subject of the branch's first commit")
+	submitCmd.Flags().StringVar(&submitBodyFile, "body-file", "", "read the body of new PRs from a file (\"-\" for stdin)")
+	submitCmd.Flags().BoolVar(&submitNoSection, "no-stack-section", false, "leave the stack section out of this stack's PR descriptions")
+	submitCmd.Flags().IntVar(&submitMaxTitleLen, "max-title-length", defaultMaxTitleLength, "truncate new PR titles longer than this")
 	submitCmd.Flags().BoolVar(&submitForce, "force", false, "skip the 'not synced' warning")
+	submitCmd.Flags().IntVarP(&submitJobs, "jobs", "j", 1, "number of branches to push and PRs to create in parallel")
+	submitCmd.Flags().BoolVar(&submitTargetBase, "target-stack-base", true, "retarget existing PRs to their parent in the stack")
+	submitCmd.Flags().StringSliceVar(&submitOnly, "only", nil, "only submit these branches")
+	submitCmd.Flags().StringSliceVar(&submitExclude, "exclude", nil, "submit every branch except these")
+	submitCmd.MarkFlagsMutuallyExclusive("only", "exclude")
 	rootCmd.AddCommand(submitCmd)
 }
 
 func runSubmit(cmd *cobra.Command, args []string) error {
 	stk := RequireStack()
 	RequireCleanTree()
+	if err := applyNoStackSection(cmd, stk, submitNoSection); err != nil {
+		return err
+	}
 
 	if len(stk.Branches) == 0 {
 		ui.Info("Stack has no branches to submit")
 		return nil
 	}
 
+	only, err := submitFilter(stk)
+	if err != nil {
+		return err
+	}
+
+	bodyText, err := readBodyFile(submitBodyFile)
+	if err != nil {
+		return err
+	}
+
 	// Step 1: Check if base branch is synced
 	if !submitForce {
 		if err := checkBaseSynced(stk); err != nil {
@@ -70,15 +131,16 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 
 	// Step 2: Push all branches
 	fmt.Println(ui.IconArrow + " Pushing branches to origin...")
-	for _, branch := range stk.Branches {
-		fmt.Printf("  Pushing %s...\n", branch.Name)
-		if err := Git().Push("origin", branch.Name, true); err != nil {
-			return fmt.Errorf("failed to push %s: %w", branch.Name, err)
-		}
+	if err := pushBranches(stk, submitJobs, only); err != nil {
+		return err
 	}
 
-	// Get provider for PR operations
-	provider, err := getProvider()
+	// Get provider for PR operations; with a fork, PRs live upstream
+	target, err := resolvePRTarget("")
+	var provider pr.Provider
+	if err == nil {
+		provider, err = providerFor(target)
+	}
 	if err != nil {
 		if !submitNoCreatePRs || !submitNoUpdatePRs {
 			ui.Warning("Failed to get PR provider: %v", err)
@@ -101,19 +163,78 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Step 3: Create PRs for branches without one
+	var createdURLs []string
 	if !submitNoCreatePRs && provider != nil {
 		fmt.Println()
 		fmt.Println(ui.IconArrow + " Creating PRs...")
+		reviewers := newPRReviewers(provider, submitReviewers)
+		warnUnsupported(provider, submitDraft, nil, reviewers)
+
+		// Record a created PR; shared by the serial and concurrent paths.
+		// Only auth failures abort the submit.
+		record := func(branch string, reviewers []string, newPR *pr.PR, err error) error {
+			// The PR exists even if setting up the rest of it failed
+			if errors.Is(err, pr.ErrIncomplete) && newPR != nil {
+				ui.Warning("%v", err)
+				err = nil
+			}
+			if err := authFailure(provider, err); err != nil {
+				return err
+			}
+			if errors.Is(err, pr.ErrPRExists) {
+				reportExistingPR(branch)
+				return nil
+			}
+			if err != nil {
+				ui.Warning("Failed to create PR for %s: %v", branch, err)
+				return nil
+			}
+
+			// Update stack metadata
+			_ = Manager().UpdatePR(stk, branch, &stack.PR{
+				Number: newPR.Number,
+				URL:    newPR.URL,
+				State:  newPR.State,
+				Title:  newPR.Title,
+				Base:   newPR.Base,
+			})
+
+			// Update branchInfos for subsequent PRs
+			branchInfos[stk.FindBranch(branch)].PR = newPR
+			createdURLs = append(createdURLs, newPR.URL)
+
+			ui.Success("Created PR #%d: %s", newPR.Number, newPR.URL)
+			applyPeopleChanges(provider, newPR.Number, reviewers, nil, nil)
+			return nil
+		}
+
+		var pending []pendingPR
+
+		// Recorded PR states may be stale, so always check the remote
+		cache := newPRCache(true)
+		defer cache.save()
 
-		created := false
 		for i, branch := range stk.Branches {
-			// Skip if PR already exists
-			if branch.PR != nil && branch.PR.Number > 0 {
+			// Skip branches that aren't being submitted or already have a PR
+			if !only.has(branch.Name) {
+				continue
+			}
+			skip, err := skipTrackedPR(stk, provider, cache, branch, submitReopen, false)
+			if err != nil {
+				return err
+			}
+			if skip {
 				continue
 			}
+			if branch.PR != nil && branch.PR.Number > 0 {
+				branchInfos[i].PR = nil
+			}
 
 			// Check if there's already an open PR for this branch on remote
 			existingPR, err := provider.GetByBranch(branch.Name)
+			if err := authFailure(provider, err); err != nil {
+				return err
+			}
 			if err == nil && existingPR != nil {
 				fmt.Printf("  Found existing PR #%d for %s\n", existingPR.Number, branch.Name)
 				_ = Manager().UpdatePR(stk, branch.Name, &stack.PR{
@@ -121,66 +242,76 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 					URL:    existingPR.URL,
 					State:  existingPR.State,
 					Title:  existingPR.Title,
+					Base:   existingPR.Base,
 				})
 				branchInfos[i].PR = existingPR
 				continue
 			}
 
 			// Determine base branch
-			var base string
-			idx := stk.FindBranch(branch.Name)
-			if idx == 0 {
-				base = stk.Base
-			} else {
-				base = stk.Branches[idx-1].Name
+			base := stk.GetPRBase(branch.Name)
+			if err := requireRemoteBase(stk, base, target.Remote); err != nil {
+				return err
+			}
+			if !only.has(base) && !Git().RemoteBranchExists("origin", base) {
+				ui.Warning("Not creating a PR for %s: its parent %s isn't on origin; submit it too", branch.Name, base)
+				continue
+			}
+			if skipEmptyBranch(branch.Name, base) {
+				continue
 			}
 
 			// Determine title
-			title := submitTitle
-			if title == "" {
-				title = branch.Name
-			}
+			title := prTitle(submitTitle, branch.Name, base, submitFill, submitMaxTitleLen)
 
 			// Generate body with stack section
-			body := pr.GenerateStackSection(stk.Name, branchInfos, branch.Name)
+			body := newPRBody(stk, bodyText, branchInfos, branch.Name)
 
 			fmt.Printf("  Creating PR for %s → %s...\n", branch.Name, base)
 
-			newPR, err := provider.Create(pr.CreateOptions{
+			opts := pr.CreateOptions{
 				Title:     title,
 				Body:      body,
 				Head:      branch.Name,
 				Base:      base,
 				Draft:     submitDraft,
-				Reviewers: submitReviewers,
-			})
-			if err != nil {
-				ui.Warning("Failed to create PR for %s: %v", branch.Name, err)
+				Reviewers: reviewers,
+				HeadOwner: target.HeadOwner,
+			}
+			if submitJobs > 1 {
+				pending = append(pending, pendingPR{branch: branch.Name, opts: opts})
 				continue
 			}
 
-			// Update stack metadata
-			_ = Manager().UpdatePR(stk, branch.Name, &stack.PR{
-				Number: newPR.Number,
-				URL:    newPR.URL,
-				State:  newPR.State,
-				Title:  newPR.Title,
-			})
-
-			// Update branchInfos for subsequent PRs
-			branchInfos[i].PR = newPR
-			created = true
+			newPR, err := createPR(provider, opts)
+			if err := record(branch.Name, opts.Reviewers, newPR, err); err != nil {
+				return err
+			}
+		}
 
-			ui.Success("Created PR #%d: %s", newPR.Number, newPR.URL)
+		if len(pending) > 0 {
+			for k, result := range createPRsConcurrently(provider, pending, submitJobs) {
+				if err := record(pending[k].branch, pending[k].opts.Reviewers, result.pr, result.err); err != nil {
+					return err
+				}
+			}
 		}
 
-		if !created {
+		if len(createdURLs) == 0 {
 			fmt.Println("  No new PRs to create")
 		}
 	}
 
-	// Step 4: Update existing PR descriptions
-	if !submitNoUpdatePRs && provider != nil {
+	// Step 4: Retarget PRs whose base is stale
+	if submitTargetBase && provider != nil {
+		stk, _ = Manager().Current()
+		if err := retargetStackPRs(stk, provider, only); err != nil {
+			return err
+		}
+	}
+
+	// Step 5: Update existing PR descriptions
+	if !submitNoUpdatePRs && provider != nil && stackSectionEnabled(stk) {
 		// Reload stack to get updated PR info
 		stk, _ = Manager().Current()
 
@@ -200,16 +331,26 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 			branchInfos = collectBranchInfos(stk, provider, false)
 
 			for _, branch := range stk.Branches {
-				if branch.PR == nil || branch.PR.Number == 0 {
+				if branch.PR == nil || branch.PR.Number == 0 || !only.has(branch.Name) {
 					continue
 				}
 				if branch.PR.State == "merged" || branch.PR.State == "closed" {
 					continue
 				}
 
-				body := pr.GenerateStackSection(stk.Name, branchInfos, branch.Name)
+				body, err := updatedBody(provider, branch.PR.Number, stackSection(stk, branchInfos, branch.Name))
+				if err != nil {
+					if authErr := authFailure(provider, err); authErr != nil {
+						return authErr
+					}
+					ui.Warning("Skipping PR #%d: %v", branch.PR.Number, err)
+					continue
+				}
 				fmt.Printf("  Updating PR #%d (%s)...\n", branch.PR.Number, branch.Name)
 				if err := provider.Update(branch.PR.Number, pr.UpdateOptions{Body: &body}); err != nil {
+					if authErr := authFailure(provider, err); authErr != nil {
+						return authErr
+					}
 					ui.Warning("Failed to update PR #%d: %v", branch.PR.Number, err)
 				}
 			}
@@ -218,36 +359,185 @@ func runSubmit(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 	ui.Success("Submit complete")
+
+	if submitOpen && len(createdURLs) > 0 {
+		fmt.Println()
+		openURLs(createdURLs)
+	}
+	return nil
+}
+
+// branchFilter is the set of branches a command works on. A nil filter
+// selects every branch.
+type branchFilter map[string]bool
+
+// has reports whether the filter selects a branch.
+func (f branchFilter) has(name string) bool {
+	return f == nil || f[name]
+}
+
+// submitFilter builds the branch filter for --only / --exclude, checking
+// that every named branch is in the stack.
+func submitFilter(stk *stack.Stack) (branchFilter, error) {
+	names := submitOnly
+	if len(submitExclude) > 0 {
+		names = submitExclude
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	for _, name := range names {
+		if !stk.HasBranch(name) {
+			return nil, fmt.Errorf("branch %q not in stack", name)
+		}
+	}
+
+	only := make(branchFilter)
+	if len(submitOnly) > 0 {
+		for _, name := range submitOnly {
+			only[name] = true
+		}
+		return only, nil
+	}
+	for _, b := range stk.Branches {
+		only[b.Name] = true
+	}
+	for _, name := range submitExclude {
+		delete(only, name)
+	}
+	return only, nil
+}
+
+// pushBranches pushes the branches selected by only to origin, up to jobs
+// at a time. Parallel pushes run quietly and set upstreams afterwards, one
+// at a time; results are reported in stack order.
+func pushBranches(stk *stack.Stack, jobs int, only branchFilter) error {
+	var names []string
+	for _, b := range stk.Branches {
+		if !only.has(b.Name) {
+			continue
+		}
+		if b.Frozen {
+			fmt.Printf("  Skipping %s (frozen)\n", b.Name)
+			continue
+		}
+		names = append(names, b.Name)
+	}
+
+	// Pushing the same ref twice at once would race; stacks shouldn't
+	// contain duplicates, but stay serial if one does
+	if hasDuplicates(names) {
+		jobs = 1
+	}
+
+	if jobs <= 1 || len(names) <= 1 {
+		// One push for the whole stack is faster; if it fails, push one
+		// branch at a time to find out which branch is the problem
+		if len(names) > 1 && !hasDuplicates(names) {
+			fmt.Printf("  Pushing %s...\n", strings.Join(names, ", "))
+			if err := Git().PushMany("origin", names, true); err == nil {
+				return nil
+			}
+			ui.Warning("Pushing all branches at once failed; retrying one at a time")
+		}
+		for _, name := range names {
+			fmt.Printf("  Pushing %s...\n", name)
+			if err := Git().Push("origin", name, true); err != nil {
+				return fmt.Errorf("failed to push %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(names))
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = Git().PushQuiet("origin", name, true)
+		}(i, name)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, name := range names {
+		if errs[i] != nil {
+			ui.Error("Failed to push %s: %v", name, errs[i])
+			failed = append(failed, name)
+			continue
+		}
+		fmt.Printf("  Pushed %s\n", name)
+		if err := Git().SetUpstream(name, "origin/"+name); err != nil {
+			ui.Warning("Failed to set upstream of %s: %v", name, err)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to push %s", strings.Join(failed, ", "))
+	}
 	return nil
 }
 
+// hasDuplicates reports whether names lists any name more than once.
+func hasDuplicates(names []string) bool {
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			return true
+		}
+		seen[name] = true
+	}
+	return false
+}
+
 // checkBaseSynced verifies the base branch is up to date with remote.
 func checkBaseSynced(stk *stack.Stack) error {
+	ahead, behind, ok := baseSyncState(stk.Base)
+	if !ok {
+		return nil // Can't check, proceed anyway
+	}
+
+	// Only fail if local is strictly behind
+	if behind > 0 && ahead == 0 {
+		return fmt.Errorf("base branch %s is %d commit(s) behind origin; run 'stk sync' first (use --force to submit anyway)", stk.Base, behind)
+	}
+
+	return nil
+}
+
+// baseSyncState compares a local branch with origin/<branch> and returns
+// how many commits it is ahead and behind. ok is false when there is no
+// remote branch or the comparison fails.
+func baseSyncState(base string) (ahead, behind int, ok bool) {
 	// Check if remote branch exists
-	if !Git().RemoteBranchExists("origin", stk.Base) {
-		return nil // No remote to compare against
+	if !Git().RemoteBranchExists("origin", base) {
+		return 0, 0, false // No remote to compare against
 	}
 
-	localSHA, err := Git().SHA(stk.Base)
+	localSHA, err := Git().SHA(base)
 	if err != nil {
-		return nil // Can't check, proceed anyway
+		return 0, 0, false
 	}
 
-	remoteSHA, err := Git().SHA("origin/" + stk.Base)
+	remoteSHA, err := Git().SHA("origin/" + base)
 	if err != nil {
-		return nil // Can't check, proceed anyway
+		return 0, 0, false
 	}
 
 	if localSHA == remoteSHA {
-		return nil // In sync
+		return 0, 0, true // In sync
 	}
 
-	// Check if local is behind
-	if Git().IsAncestor(localSHA, remoteSHA) {
-		// Count how many commits behind
-		count, _ := Git().CommitCount(localSHA, remoteSHA)
-		return fmt.Errorf("base branch %s is %d commit(s) behind origin; run 'stk sync' first (use --force to submit anyway)", stk.Base, count)
+	behind, err = Git().CommitCount(localSHA, remoteSHA)
+	if err != nil {
+		return 0, 0, false
 	}
-
-	return nil
+	ahead, err = Git().CommitCount(remoteSHA, localSHA)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ahead, behind, true
 }
@@ -1,10 +1,13 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/stefanaki/stk/internal/pr"
 	"github.com/stefanaki/stk/internal/stack"
 	"github.com/stefanaki/stk/internal/ui"
 )
@@ -21,17 +24,48 @@ This command performs the following steps:
   4. Process merged PRs (remove from stack, retarget downstream PRs)
   5. Process closed PRs (clear PR metadata, will recreate on submit)
   6. Rebase entire stack onto updated base
+  7. Report branches left with no commits of their own
+  8. List branches that 'stk submit' will force-push
 
 This command never pushes to the remote. Use 'stk submit' to push and manage PRs.
 
 Use --no-fetch to skip fetching (local rebase only).
 Use --no-rebase to only refresh PR states.
+Use --ff-base to fast-forward the base branch to origin instead of running
+'git pull --rebase' on it, for bases you never commit to locally. If the
+base has local-only commits, sync warns and rebases it as usual.
+Use --no-pr to skip every step that talks to the PR provider (steps 3-5),
+for a plain fetch, base update and rebase. This is automatic when origin
+isn't a GitHub or GitLab remote.
+Use --clear-missing to unlink PRs the provider reports as not found. By
+default they're only reported, since a token without access to the
+repository gets the same answer.
+Use --force-refresh to fetch PR states even if they were fetched within
+the last pr_cache_ttl (60s by default, set in .stk.yaml).
 Use --delete-merged to delete local branches for merged PRs.
+Use --prune-remote to also delete their branches on the remote. Branches
+that can't be deleted (e.g. protected ones) are reported and skipped.
+Use --prune-empty to remove branches whose commits are already in their
+parent (e.g. cherry-picked into base) and close their PRs.
+Use --keep-empty to keep commits that are or become empty during the
+rebase (e.g. because a lower branch's changes already landed in base), or
+--no-keep-empty to drop them. Without either, git's defaults apply.
+Use --autostash to stash uncommitted changes (including untracked files)
+before syncing instead of refusing to run, and pop them once the sync is
+done, also when a failed rebase is rolled back. If the sync stops partway
+(e.g. for an interactive rebase), the changes stay stashed.
+Use --interactive (-i) to rebase the first branch onto the base with
+'git rebase -i', so you can drop or edit its commits before the rest of
+the stack is rebased on top. If the interactive rebase stops for a
+conflict or an edit, the whole sync pauses: finish it with
+'git rebase --continue', then run 'stk sync' again to rebase the rest.
 
 Examples:
   stk sync                # Full sync with remote
   stk sync --no-fetch     # Local rebase only
-  stk sync --no-rebase    # Only refresh PR states`,
+  stk sync --no-rebase    # Only refresh PR states
+  stk sync --no-pr        # Git only, no PR provider
+  stk sync -i             # Edit the first branch's commits while rebasing`,
 	RunE: runSync,
 }
 
@@ -39,24 +73,108 @@ var (
 	syncNoFetch      bool
 	syncNoRebase     bool
 	syncDeleteMerged bool
+	syncPruneRemote  bool
+	syncPruneEmpty   bool
+	syncInteractive  bool
+	syncAutostash    bool
+	syncKeepEmpty    bool
+	syncNoKeepEmpty  bool
+	syncForceRefresh bool
+	syncFFBase       bool
+	syncNoPR         bool
+	syncClearMissing bool
 )
 
 func init() {
 	syncCmd.Flags().BoolVar(&syncNoFetch, "no-fetch", false, "skip fetching from remote")
 	syncCmd.Flags().BoolVar(&syncNoRebase, "no-rebase", false, "only refresh PR states, don't rebase")
 	syncCmd.Flags().BoolVar(&syncDeleteMerged, "delete-merged", false, "delete local branches for merged PRs")
+	syncCmd.Flags().BoolVar(&syncPruneRemote, "prune-remote", false, "delete remote branches for merged PRs")
+	syncCmd.Flags().BoolVarP(&syncInteractive, "interactive", "i", false, "rebase the first branch interactively")
+	syncCmd.Flags().BoolVar(&syncAutostash, "autostash", false, "stash uncommitted changes before syncing and restore them afterwards")
+	syncCmd.Flags().BoolVar(&syncPruneEmpty, "prune-empty", false, "remove branches left with no commits after rebase and close their PRs")
+	syncCmd.Flags().BoolVar(&syncKeepEmpty, "keep-empty", false, "keep empty commits when rebasing")
+	syncCmd.Flags().BoolVar(&syncNoKeepEmpty, "no-keep-empty", false, "drop empty commits when rebasing")
+	syncCmd.Flags().BoolVar(&syncFFBase, "ff-base", false, "fast-forward the base branch instead of pull --rebase")
+	syncCmd.Flags().BoolVar(&syncForceRefresh, "force-refresh", false, "refresh PR states even if the PR cache is fresh")
+	syncCmd.Flags().BoolVar(&syncNoPR, "no-pr", false, "don't use the PR provider (git-only sync)")
+	syncCmd.Flags().BoolVar(&syncClearMissing, "clear-missing", false, "clear the PR link of branches whose PR the provider can't find")
+	syncCmd.MarkFlagsMutuallyExclusive("no-pr", "no-rebase")
+	syncCmd.MarkFlagsMutuallyExclusive("no-pr", "force-refresh")
+	syncCmd.MarkFlagsMutuallyExclusive("keep-empty", "no-keep-empty")
 	rootCmd.AddCommand(syncCmd)
 }
 
+// syncSummary collects counts for the report printed at the end of a sync.
+type syncSummary struct {
+	rebased  int
+	merged   int
+	closed   int
+	pruned   int
+	warnings int
+}
+
+// warn prints a warning and records it in the summary. A nil summary,
+// for commands that don't report one, only prints the warning.
+func (s *syncSummary) warn(format string, args ...interface{}) {
+	if s != nil {
+		s.warnings++
+	}
+	ui.Warning(format, args...)
+}
+
+// print writes the summary report.
+func (s *syncSummary) print() {
+	fmt.Printf("  %d branch(es) rebased, %d merged PR(s) removed, %d closed PR(s) cleared\n",
+		s.rebased, s.merged, s.closed)
+	if s.pruned > 0 {
+		fmt.Printf("  %d empty branch(es) pruned\n", s.pruned)
+	}
+	if s.warnings > 0 {
+		fmt.Printf("  %s%d warning(s) occurred; see output above%s\n", ui.Yellow, s.warnings, ui.Reset)
+	}
+}
+
+// updateBase brings the checked-out base branch up to date with origin.
+// With --ff-base it fast-forwards instead of rebasing, unless the base has
+// commits that aren't on the remote.
+func updateBase(base string, summary *syncSummary) error {
+	if syncFFBase {
+		if Git().IsAncestor(base, "origin/"+base) {
+			return Git().MergeFFOnly("origin/" + base)
+		}
+		summary.warn("Base branch %s has local commits not on origin; updating with pull --rebase instead", base)
+	}
+	return Git().Run("pull", "--rebase", "origin", base)
+}
+
 func runSync(cmd *cobra.Command, args []string) error {
 	stk := RequireStack()
-	RequireCleanTree()
+	if syncAutostash {
+		sha, err := stashForSync()
+		if err != nil {
+			return err
+		}
+		// Runs after restoreBranch below, once back on the starting branch
+		defer restoreAutostash(sha)
+	} else {
+		RequireCleanTree()
+	}
+
+	// Every step returns to the branch sync started on, however it exits
+	originalBranch, _ := Git().CurrentBranch()
+	defer restoreBranch(originalBranch)
+
+	var summary syncSummary
+
+	// A repository without origin is local-only, not misconfigured
+	hasOrigin := Git().HasRemote("origin")
 
 	// Step 1: Fetch
-	if !syncNoFetch {
+	if !syncNoFetch && hasOrigin {
 		fmt.Println(ui.IconArrow + " Fetching from origin...")
 		if err := Git().Fetch("origin"); err != nil {
-			ui.Warning("Failed to fetch: %v", err)
+			summary.warn("Failed to fetch: %v", err)
 		}
 	}
 
@@ -64,43 +182,72 @@ func runSync(cmd *cobra.Command, args []string) error {
 	if !syncNoRebase && Git().RemoteBranchExists("origin", stk.Base) {
 		fmt.Printf("%s Updating base branch %s...\n", ui.IconArrow, stk.Base)
 
-		originalBranch, _ := Git().CurrentBranch()
-
 		if err := Git().Checkout(stk.Base); err != nil {
 			return fmt.Errorf("failed to checkout base: %w", err)
 		}
 
-		if err := Git().Run("pull", "--rebase", "origin", stk.Base); err != nil {
-			ui.Warning("Failed to update base branch: %v", err)
+		if err := updateBase(stk.Base, &summary); err != nil {
+			summary.warn("Failed to update base branch: %v", err)
 		}
 
-		if originalBranch != "" && originalBranch != stk.Base {
-			_ = Git().CheckoutSilent(originalBranch)
-		}
+		restoreBranch(originalBranch)
 	}
 
 	// Step 3: Refresh PR states from remote
-	fmt.Println()
-	fmt.Println(ui.IconArrow + " Refreshing PR states...")
-
-	provider, err := getProvider()
-	if err != nil {
-		ui.Warning("Failed to get PR provider: %v", err)
-		provider = nil
+	var provider pr.Provider
+	if !syncNoPR {
+		fmt.Println()
+		fmt.Println(ui.IconArrow + " Refreshing PR states...")
+
+		var err error
+		if !hasOrigin {
+			ui.DimText("  No origin remote; skipping PR steps")
+		} else if provider, err = getProvider(); errors.Is(err, pr.ErrUnsupportedRemote) {
+			ui.DimText("  origin isn't a GitHub or GitLab remote; skipping PR steps")
+			provider = nil
+		} else if err != nil {
+			summary.warn("Failed to get PR provider: %v", err)
+			provider = nil
+		}
 	}
 
 	var mergedBranches []string
 	var closedBranches []string
 
 	if provider != nil {
+		cache := newPRCache(syncForceRefresh)
+		defer cache.save()
+
 		for _, branch := range stk.Branches {
 			if branch.PR == nil || branch.PR.Number == 0 {
 				continue
 			}
 
-			remotePR, err := provider.Get(branch.PR.Number)
+			remotePR, err := cache.get(provider, branch.PR.Number)
+
+			// A cached "open" must not undo a merge or close recorded
+			// since it was fetched; ask the provider instead
+			if err == nil && cache.cached(branch.PR.Number) &&
+				(branch.PR.State == "merged" || branch.PR.State == "closed") && remotePR.State != branch.PR.State {
+				cache.forget(branch.PR.Number)
+				remotePR, err = cache.get(provider, branch.PR.Number)
+			}
+			if err := authFailure(provider, err); err != nil {
+				return err
+			}
+			// Providers also answer 404 for missing token scopes or the
+			// wrong repository, so the link is only dropped on request
+			if errors.Is(err, pr.ErrNotFound) {
+				if syncClearMissing {
+					summary.warn("PR #%d (%s) wasn't found; clearing PR metadata", branch.PR.Number, branch.Name)
+					_ = Manager().UpdatePR(stk, branch.Name, nil)
+				} else {
+					summary.warn("PR #%d (%s) wasn't found; check your token's access, or use --clear-missing to unlink it", branch.PR.Number, branch.Name)
+				}
+				continue
+			}
 			if err != nil {
-				ui.Warning("Failed to fetch PR #%d: %v", branch.PR.Number, err)
+				summary.warn("Failed to fetch PR #%d: %v", branch.PR.Number, err)
 				continue
 			}
 
@@ -110,6 +257,8 @@ func runSync(cmd *cobra.Command, args []string) error {
 				URL:    remotePR.URL,
 				State:  remotePR.State,
 				Title:  remotePR.Title,
+				Base:   remotePR.Base,
+				Author: remotePR.Author,
 			})
 
 			switch remotePR.State {
@@ -163,7 +312,12 @@ func runSync(cmd *cobra.Command, args []string) error {
 						if i == idx+1 {
 							fmt.Printf("  Retargeting PR #%d to %s\n", downstream.PR.Number, targetBase)
 							if err := provider.Retarget(downstream.PR.Number, targetBase); err != nil {
-								ui.Warning("Failed to retarget PR #%d: %v", downstream.PR.Number, err)
+								if authErr := authFailure(provider, err); authErr != nil {
+									return authErr
+								}
+								summary.warn("Failed to retarget PR #%d: %v", downstream.PR.Number, err)
+							} else {
+								recordPRBase(stk, downstream.Name, targetBase)
 							}
 						}
 					}
@@ -171,15 +325,27 @@ func runSync(cmd *cobra.Command, args []string) error {
 			}
 
 			// Remove from stack
+			recordMergedParentTip(stk, branchName)
 			if err := Manager().RemoveBranch(stk, branchName); err != nil {
-				ui.Warning("Failed to remove %s from stack: %v", branchName, err)
+				summary.warn("Failed to remove %s from stack: %v", branchName, err)
+			} else {
+				summary.merged++
+			}
+
+			// Optionally delete the remote branch; it may already be gone
+			// if the provider deletes head branches on merge
+			if syncPruneRemote && provider != nil {
+				fmt.Printf("  Deleting remote branch %s\n", branchName)
+				if err := provider.DeleteBranch(branchName); err != nil && !errors.Is(err, pr.ErrNotFound) {
+					summary.warn("Failed to delete remote branch %s: %v", branchName, err)
+				}
 			}
 
 			// Optionally delete local branch
 			if syncDeleteMerged {
 				fmt.Printf("  Deleting local branch %s\n", branchName)
 				if err := Git().DeleteBranch(branchName, true); err != nil {
-					ui.Warning("Failed to delete branch %s: %v", branchName, err)
+					summary.warn("Failed to delete branch %s: %v", branchName, err)
 				}
 			}
 		}
@@ -193,6 +359,7 @@ func runSync(cmd *cobra.Command, args []string) error {
 		for _, branchName := range closedBranches {
 			fmt.Printf("  Cleared PR metadata for %s (will recreate on submit)\n", branchName)
 			_ = Manager().UpdatePR(stk, branchName, nil)
+			summary.closed++
 		}
 	}
 
@@ -202,19 +369,221 @@ func runSync(cmd *cobra.Command, args []string) error {
 	// Step 6: Rebase stack
 	if !syncNoRebase && len(stk.Branches) > 0 {
 		fmt.Println()
-		if err := rebaseStack(stk); err != nil {
+		hadCommits := branchesWithCommits(stk)
+		if err := rebaseStack(stk, syncInteractive, emptyRebaseArgs(), &summary); err != nil {
 			return err
 		}
+
+		// Step 7: Handle branches left empty by the rebase
+		if err := handleEmptyBranches(stk, provider, hadCommits, &summary); err != nil {
+			return err
+		}
+
+		stk, _ = Manager().Current()
+		reportDiverged(stk)
 	}
 
 	fmt.Println()
 	ui.Success("Sync complete")
+	summary.print()
 	return nil
 }
 
-// rebaseStack rebases all branches in the stack atomically.
-func rebaseStack(stk *stack.Stack) error {
-	if len(stk.Branches) == 0 {
+// restoreBranch checks out branch again after a command switched branches,
+// unless a rebase or other operation stopped partway and the user has to
+// finish it where it is. A branch that no longer exists is left alone.
+func restoreBranch(branch string) {
+	if branch == "" || !Git().BranchExists(branch) {
+		return
+	}
+	if Git().IsRebaseInProgress() || Git().OperationInProgress() != "" {
+		return
+	}
+	if current, _ := Git().CurrentBranch(); current != branch {
+		_ = Git().CheckoutSilent(branch)
+	}
+}
+
+// reportDiverged lists branches that no longer contain their pushed
+// version, which the next 'stk submit' will force-push.
+func reportDiverged(stk *stack.Stack) {
+	var lines []string
+	for _, b := range stk.Branches {
+		remote := "origin/" + b.Name
+		if !Git().RemoteBranchExists("origin", b.Name) || Git().IsAncestor(remote, b.Name) {
+			continue
+		}
+		ahead, errA := Git().CommitCount(remote, b.Name)
+		behind, errB := Git().CommitCount(b.Name, remote)
+		if errA != nil || errB != nil {
+			lines = append(lines, "  "+b.Name)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s (%d ahead, %d behind %s)", b.Name, ahead, behind, remote))
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(ui.IconInfo + " These branches now differ from origin; 'stk submit' will force-push them:")
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
+
+// recordMergedParentTip remembers the local tip of a merged branch on its
+// child before the merged branch leaves the stack, so the child's next
+// rebase skips the merged commits even if they were squashed.
+func recordMergedParentTip(stk *stack.Stack, merged string) {
+	children := stk.GetChildren(merged)
+	if len(children) == 0 {
+		return
+	}
+	sha, err := Git().SHA(merged)
+	if err != nil || !Git().IsAncestor(sha, children[0]) {
+		return
+	}
+	_ = Manager().SetMergedParentTip(stk, children[0], sha)
+}
+
+// emptyRebaseArgs returns the 'git rebase' arguments for --keep-empty or
+// --no-keep-empty. They cover both commits that start out empty and ones
+// that become empty, which git treats separately.
+func emptyRebaseArgs() []string {
+	switch {
+	case syncKeepEmpty:
+		return []string{"--keep-empty", "--empty=keep"}
+	case syncNoKeepEmpty:
+		return []string{"--no-keep-empty", "--empty=drop"}
+	}
+	return nil
+}
+
+// branchesWithCommits returns the branches that have commits on top of
+// their parent, recorded before a rebase so handleEmptyBranches can tell
+// which ones the rebase emptied.
+func branchesWithCommits(stk *stack.Stack) map[string]bool {
+	had := make(map[string]bool)
+	for _, branch := range stk.Branches {
+		count, err := Git().CommitCount(stk.GetParent(branch.Name), branch.Name)
+		if err == nil && count > 0 {
+			had[branch.Name] = true
+		}
+	}
+	return had
+}
+
+// handleEmptyBranches finds branches the rebase left with no commits on top
+// of their parent. Branches that had none before the rebase (hadCommits) are
+// new and left alone. With --prune-empty they are removed from the stack and
+// their PRs closed; otherwise they are only reported.
+func handleEmptyBranches(stk *stack.Stack, provider pr.Provider, hadCommits map[string]bool, summary *syncSummary) error {
+	var empty []string
+	for _, branch := range stk.Branches {
+		if !hadCommits[branch.Name] {
+			continue
+		}
+		count, err := Git().CommitCount(stk.GetParent(branch.Name), branch.Name)
+		if err == nil && count == 0 {
+			empty = append(empty, branch.Name)
+		}
+	}
+	if len(empty) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	if !syncPruneEmpty {
+		summary.warn("%d branch(es) have no commits of their own after rebase:", len(empty))
+		for _, name := range empty {
+			fmt.Printf("  %s\n", name)
+		}
+		ui.DimText("  Their changes are already in the parent; use --prune-empty to remove them")
+		return nil
+	}
+
+	fmt.Println(ui.IconArrow + " Pruning empty branches...")
+	for _, name := range empty {
+		stk, _ = Manager().Current()
+		idx := stk.FindBranch(name)
+		if idx < 0 {
+			continue
+		}
+		branch := stk.Branches[idx]
+
+		fmt.Printf("  Removing %s from stack (no commits left)\n", name)
+		if provider != nil && branch.PR != nil && branch.PR.Number > 0 &&
+			branch.PR.State != "merged" && branch.PR.State != "closed" {
+			fmt.Printf("  Closing PR #%d\n", branch.PR.Number)
+			if err := provider.Close(branch.PR.Number); err != nil {
+				if authErr := authFailure(provider, err); authErr != nil {
+					return authErr
+				}
+				summary.warn("Failed to close PR #%d: %v", branch.PR.Number, err)
+			} else {
+				forgetCachedPR(branch.PR.Number)
+			}
+		}
+
+		if err := Manager().RemoveBranch(stk, name); err != nil {
+			summary.warn("Failed to remove %s from stack: %v", name, err)
+			continue
+		}
+		summary.pruned++
+	}
+
+	// Children of pruned branches now sit on a different parent
+	if provider != nil {
+		stk, _ = Manager().Current()
+		if err := retargetStackPRs(stk, provider, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebaseStack rebases all branches in the stack atomically, passing
+// rebaseArgs to each 'git rebase'. With interactive set, the first branch is
+// rebased with 'git rebase -i'; if that rebase stops, the snapshot is kept
+// and the sync pauses until it's done. Branches that moved are counted in
+// summary, which also collects warnings; it may be nil.
+func rebaseStack(stk *stack.Stack, interactive bool, rebaseArgs []string, summary *syncSummary) error {
+	return restackBranches(stk, restackOptions{
+		interactive: interactive,
+		rebaseArgs:  rebaseArgs,
+		summary:     summary,
+	})
+}
+
+// restackOptions configures restackBranches.
+type restackOptions struct {
+	// start is the index of the first branch to rebase; branches below it
+	// are left alone.
+	start int
+
+	// onto and upstream, when onto is set, replace the first branch's new
+	// parent and the commit its own commits start after.
+	onto, upstream string
+
+	// oldParents maps branches to their parent before a reorder. Their
+	// own commits start after that parent's tip rather than the new one's.
+	oldParents map[string]string
+
+	interactive bool // rebase the first branch with 'git rebase -i'
+	rebaseArgs  []string
+
+	// summary counts the branches that moved and collects warnings; it
+	// may be nil.
+	summary *syncSummary
+}
+
+// restackBranches rebases the branches of a stack from opts.start up, each
+// onto its parent, replaying only the commits made on top of the parent's
+// old tip. A snapshot taken first lets a failed rebase roll back every
+// branch. Frozen branches are skipped.
+func restackBranches(stk *stack.Stack, opts restackOptions) error {
+	if opts.start >= len(stk.Branches) {
 		return nil
 	}
 
@@ -227,29 +596,85 @@ func rebaseStack(stk *stack.Stack) error {
 	}); err != nil {
 		return fmt.Errorf("failed to take snapshot: %w", err)
 	}
+	if syncAutostashSHA != "" {
+		_ = Manager().SetSnapshotAutostash(stk, syncAutostashSHA)
+	}
 
 	// Perform rebases
-	for i := range stk.Branches {
+	start := time.Now()
+	total := len(stk.Branches) - opts.start
+	rebased := 0
+	for i := opts.start; i < len(stk.Branches); i++ {
 		branch := stk.Branches[i].Name
-		var base string
-		if i == 0 {
-			base = stk.Base
-		} else {
-			base = stk.Branches[i-1].Name
+		base := stk.GetParent(branch)
+		first := i == opts.start
+
+		if stk.Branches[i].Frozen {
+			fmt.Printf("%s [%d/%d] Skipping %s%s%s (frozen)\n",
+				ui.IconInfo, i-opts.start+1, total, ui.Bold, branch, ui.Reset)
+			continue
+		}
+		rebased++
+
+		upstream := ""
+		if first && opts.onto != "" {
+			base, upstream = opts.onto, opts.upstream
 		}
 
-		fmt.Printf("%s Rebasing %s%s%s onto %s%s%s\n",
-			ui.IconArrow,
+		fmt.Printf("%s [%d/%d] Rebasing %s%s%s onto %s%s%s\n",
+			ui.IconArrow, i-opts.start+1, total,
 			ui.Bold, branch, ui.Reset,
 			ui.Dim, base, ui.Reset)
 
-		if err := Git().RebaseBranchOnto(branch, base); err != nil {
+		if first && opts.interactive {
+			err := rebaseInteractive(branch, base, opts.rebaseArgs)
+			if Git().IsRebaseInProgress() {
+				return fmt.Errorf("interactive rebase of %s paused; finish it with 'git rebase --continue', then run 'stk sync' again", branch)
+			}
+			if err != nil {
+				ui.Error("Rebase failed")
+				rollbackStack(stk, originalBranch, opts.summary)
+				return fmt.Errorf("rebase failed")
+			}
+			continue
+		}
+
+		// Replay only the commits made on top of the parent's old tip
+		if upstream == "" {
+			upstream = oldParentTip(stk, base, opts.oldParents[branch], branch)
+		}
+		if err := Git().RebaseBranchOnto(branch, base, upstream, opts.rebaseArgs...); err != nil {
 			ui.Error("Rebase failed")
-			rollbackStack(stk, originalBranch)
+			rollbackStack(stk, originalBranch, opts.summary)
 			return fmt.Errorf("rebase failed")
 		}
 	}
 
+	// Branches already on top of their parent come out of the rebase as
+	// they were
+	moved := 0
+	for _, b := range stk.Branches[opts.start:] {
+		if sha, err := Git().SHA(b.Name); !b.Frozen && err == nil && sha != stk.Snapshot.Refs[b.Name] {
+			moved++
+		}
+	}
+	if opts.summary != nil {
+		opts.summary.rebased += moved
+	}
+	if upToDate := rebased - moved; upToDate > 0 {
+		fmt.Printf("  Rebased %d branch(es) in %s; %d already up to date\n",
+			moved, time.Since(start).Round(100*time.Millisecond), upToDate)
+	} else {
+		fmt.Printf("  Rebased %d branch(es) in %s\n", moved, time.Since(start).Round(100*time.Millisecond))
+	}
+
+	// The merged parents' commits are gone from every rebased branch now
+	for _, b := range stk.Branches[opts.start:] {
+		if b.MergedParentTip != "" && !b.Frozen {
+			_ = Manager().SetMergedParentTip(stk, b.Name, "")
+		}
+	}
+
 	// Clear snapshot on success
 	_ = Manager().ClearSnapshot(stk)
 
@@ -261,10 +686,59 @@ func rebaseStack(stk *stack.Stack) error {
 	return nil
 }
 
+// oldParentTip returns the parent tip branch was built on: the tip of a
+// merged parent recorded by recordMergedParentTip, the parent's snapshot
+// SHA, or, if the parent was rewritten before the snapshot (e.g. amended
+// by hand), the fork point from the parent's reflog. For a branch with a
+// base override, the snapshot tip of the branch below it in the stack
+// comes first, since that's what the branch sits on until the override
+// first detaches it. A previous parent, from before the stack was
+// reordered, takes the place of parent. It returns an empty string when
+// none is known.
+func oldParentTip(stk *stack.Stack, parent, previous, branch string) string {
+	candidates := []string{parent}
+	if idx := stk.FindBranch(branch); idx >= 0 {
+		if sha := stk.Branches[idx].MergedParentTip; sha != "" && Git().IsAncestor(sha, branch) {
+			return sha
+		}
+		switch {
+		case previous != "" && previous != parent:
+			parent = previous
+			candidates = []string{parent}
+		case stk.Branches[idx].BaseOverride != "":
+			below := stk.Base
+			if idx > 0 {
+				below = stk.Branches[idx-1].Name
+			}
+			candidates = []string{below, parent}
+		}
+	}
+	for _, name := range candidates {
+		if sha, ok := stk.Snapshot.Refs[name]; ok && Git().IsAncestor(sha, branch) {
+			return sha
+		}
+	}
+	sha, err := Git().ForkPoint(parent, branch)
+	if err != nil {
+		return ""
+	}
+	return sha
+}
+
+// rebaseInteractive checks out a branch and rebases it onto base with
+// 'git rebase -i'.
+func rebaseInteractive(branch, base string, rebaseArgs []string) error {
+	if err := Git().Checkout(branch); err != nil {
+		return fmt.Errorf("failed to checkout %s: %w", branch, err)
+	}
+	return Git().RebaseInteractive(base, rebaseArgs...)
+}
+
 // rollbackStack restores all branches to their snapshot positions.
-func rollbackStack(stk *stack.Stack, originalBranch string) {
+// Warnings are recorded in summary, which may be nil.
+func rollbackStack(stk *stack.Stack, originalBranch string, summary *syncSummary) {
 	if stk.Snapshot == nil {
-		ui.Warning("No snapshot available for rollback")
+		summary.warn("No snapshot available for rollback")
 		return
 	}
 
@@ -284,7 +758,7 @@ func rollbackStack(stk *stack.Stack, originalBranch string) {
 		}
 		fmt.Printf("  Resetting %s to %s\n", branchName, shortSHA)
 		if err := Git().ResetBranchToSHA(branchName, sha); err != nil {
-			ui.Warning("Failed to reset %s: %v", branchName, err)
+			summary.warn("Failed to reset %s: %v", branchName, err)
 		}
 	}
 
@@ -292,8 +766,59 @@ func rollbackStack(stk *stack.Stack, originalBranch string) {
 		_ = Git().CheckoutSilent(originalBranch)
 	}
 
+	// Give back changes set aside for the operation being rolled back
+	restoreAutostash(stk.Snapshot.Autostash)
+
 	_ = Manager().ClearSnapshot(stk)
 
 	fmt.Println()
 	ui.Success("Rollback complete - stack restored to original state")
 }
+
+// syncAutostashSHA is the stash made by 'stk sync --autostash' for the
+// current run, recorded on the rebase snapshot so a rollback restores it.
+var syncAutostashSHA string
+
+// stashForSync sets uncommitted changes aside for 'stk sync --autostash'
+// and returns the stash's SHA, or "" if the tree was already clean.
+func stashForSync() (string, error) {
+	if hint := rebaseInProgressHint(); hint != "" {
+		return "", errors.New(hint)
+	}
+	clean, err := Git().IsClean()
+	if err != nil {
+		return "", fmt.Errorf("failed to check working tree status: %w", err)
+	}
+	if clean {
+		return "", nil
+	}
+
+	fmt.Println(ui.IconArrow + " Stashing uncommitted changes...")
+	sha, err := Git().Stash("stk sync --autostash")
+	if err != nil {
+		return "", fmt.Errorf("failed to stash changes: %w", err)
+	}
+	syncAutostashSHA = sha
+	return sha, nil
+}
+
+// restoreAutostash pops the stash made by --autostash, if it's still
+// there. While a rebase or other operation is stopped partway, the stash
+// is left for the user to pop once they're done.
+func restoreAutostash(sha string) {
+	if sha == "" || !Git().HasStash(sha) {
+		return
+	}
+	short := sha
+	if len(short) > 8 {
+		short = short[:8]
+	}
+	if Git().IsRebaseInProgress() || Git().OperationInProgress() != "" {
+		ui.Info("Your uncommitted changes are stashed as %s; run 'git stash pop' when you're done", short)
+		return
+	}
+	fmt.Println(ui.IconArrow + " Restoring stashed changes...")
+	if err := Git().StashPop(sha); err != nil {
+		ui.Warning("Stashed changes didn't apply cleanly; resolve the conflicts (the stash %s is kept)", short)
+	}
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stefanaki/stk/internal/config"
+	"github.com/stefanaki/stk/internal/stack"
+)
+
+func TestSyncEndsOnStartingBranch(t *testing.T) {
+	dir := initRepo(t)
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	runGit(t, "init", "-q", "--bare", origin)
+	runGit(t, "remote", "add", "origin", origin)
+	runGit(t, "push", "-q", "origin", "main")
+
+	manager = stack.NewManager(filepath.Join(dir, ".git"))
+	cfg = &config.Config{}
+	stk, err := manager.Create("feature", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"models", "api"} {
+		runGit(t, "checkout", "-q", "-b", name)
+		runGit(t, "commit", "-q", "--allow-empty", "-m", name)
+		if err := manager.AppendBranch(stk, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Move main on origin so sync has to update the base and rebase the
+	// stack, checking out each branch along the way
+	runGit(t, "checkout", "-q", "main")
+	runGit(t, "commit", "-q", "--allow-empty", "-m", "upstream change")
+	runGit(t, "push", "-q", "origin", "main")
+	runGit(t, "reset", "-q", "--hard", "HEAD~1")
+	runGit(t, "checkout", "-q", "models")
+
+	if err := runSync(syncCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if current, err := Git().CurrentBranch(); err != nil || current != "models" {
+		t.Errorf("sync ended on %q (%v), want models", current, err)
+	}
+	if !Git().IsAncestor("origin/main", "api") {
+		t.Error("api wasn't rebased onto the updated main")
+	}
+}
+
+// commitStack creates a stack on main in the repository made by initRepo,
+// with one commit per branch named after it.
+func commitStack(t *testing.T, dir string, branches ...string) *stack.Stack {
+	t.Helper()
+	manager = stack.NewManager(filepath.Join(dir, ".git"))
+	cfg = &config.Config{}
+	stk, err := manager.Create("feature", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range branches {
+		runGit(t, "checkout", "-q", "-b", name)
+		runGit(t, "commit", "-q", "--allow-empty", "-m", name)
+		if err := manager.AppendBranch(stk, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return stk
+}
+
+// subjects returns the subjects of the commits in a revision range,
+// oldest first.
+func subjects(t *testing.T, revRange string) string {
+	t.Helper()
+	out, err := exec.Command("git", "log", "--reverse", "--format=%s", revRange).Output()
+	if err != nil {
+		t.Fatalf("git log %s: %v", revRange, err)
+	}
+	return strings.Join(strings.Fields(string(out)), " ")
+}
+
+func TestSyncDetachesBranchWithBaseOverride(t *testing.T) {
+	dir := initRepo(t)
+	stk := commitStack(t, dir, "models", "api")
+	if err := manager.SetBaseOverride(stk, "api", "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	syncNoFetch = true
+	t.Cleanup(func() { syncNoFetch = false })
+	if err := runSync(syncCmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := subjects(t, "main..api"); got != "api" {
+		t.Errorf("main..api has %q, want only api's commit", got)
+	}
+	if got := subjects(t, "main..models"); got != "models" {
+		t.Errorf("main..models has %q, want only models' commit", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Package config loads repository-level settings for stk.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the config file at the repository root.
+const FileName = ".stk.yaml"
+
+// Config holds settings loaded from the config file.
+type Config struct {
+	// StackSectionTemplate is a text/template used to render the stack
+	// section of PR descriptions. Empty means the built-in layout.
+	StackSectionTemplate string `yaml:"stack_section_template,omitempty" doc:"Custom layout for the stack section of PR descriptions (Go text/template).\nEmpty means the built-in table."`
+
+	// StackSection controls whether PR descriptions get a stack section.
+	// Nil means true.
+	StackSection *bool `yaml:"stack_section,omitempty" doc:"Set to false to leave the stack section out of all PR descriptions." default:"true"`
+
+	// BranchPrefix is prepended to names given to 'stk branch' that don't
+	// already contain a slash (e.g. "username/").
+	BranchPrefix string `yaml:"branch_prefix,omitempty" doc:"Prefix added to 'stk branch' names that don't contain a slash."`
+
+	// AllowSharedBranches lets a branch belong to more than one stack,
+	// without passing --force to 'stk add' and 'stk init'.
+	AllowSharedBranches bool `yaml:"allow_shared_branches,omitempty" doc:"Let a branch belong to more than one stack, as if --force were given\nto 'stk add' and 'stk init'." default:"false"`
+
+	// StrictSubmodules makes clean-tree checks also fail on submodules with
+	// modified content or a moved pointer, regardless of git's
+	// diff.ignoreSubmodules / submodule.<name>.ignore settings.
+	StrictSubmodules bool `yaml:"strict_submodules,omitempty" doc:"Treat submodules with uncommitted changes or moved pointers as a dirty\nworking tree, even if git is configured to ignore them."`
+
+	// GitBinary is the git executable stk runs. The STK_GIT environment
+	// variable takes precedence; empty means "git" on PATH.
+	GitBinary string `yaml:"git_binary,omitempty" doc:"Git executable to run (STK_GIT in the environment takes precedence)."`
+
+	// Reviewers are requested on every new PR, in addition to those given
+	// with --reviewer. STK_REVIEWERS in the environment replaces them.
+	Reviewers []string `yaml:"reviewers,omitempty" doc:"Reviewers requested on every new PR, merged with --reviewer.\n\"@me\" is you; \"team:<slug>\" is a GitHub team. STK_REVIEWERS\n(comma-separated) in the environment replaces this list."`
+
+	// PRCacheTTL is how long PR state fetched from the provider is reused
+	// (e.g. "60s", "0" to always fetch). Empty means DefaultPRCacheTTL.
+	PRCacheTTL string `yaml:"pr_cache_ttl,omitempty" doc:"How long PR state fetched from the provider is reused. \"0\" disables the cache." default:"60s"`
+
+	// IgnoredToken is set when the file has a token key. Tokens aren't
+	// read from .stk.yaml, which is usually committed; see TokenGitConfig.
+	IgnoredToken bool `yaml:"-"`
+}
+
+// TokenGitConfig is the git config key holding the API token for the PR
+// provider. It takes precedence over GITHUB_TOKEN / GITLAB_TOKEN and the
+// gh / glab CLIs. Set in .git/config (or the user's ~/.gitconfig), it
+// stays out of version control.
+const TokenGitConfig = "stk.token"
+
+// ReviewersEnv is the environment variable listing default reviewers,
+// comma-separated. When set, it replaces the configured list.
+const ReviewersEnv = "STK_REVIEWERS"
+
+// DefaultPRCacheTTL is the PR cache lifetime when none is configured.
+const DefaultPRCacheTTL = 60 * time.Second
+
+// PRCacheDuration returns the configured PR cache lifetime.
+func (c *Config) PRCacheDuration() time.Duration {
+	if c == nil || c.PRCacheTTL == "" {
+		return DefaultPRCacheTTL
+	}
+	d, err := parseTTL(c.PRCacheTTL)
+	if err != nil {
+		return DefaultPRCacheTTL
+	}
+	return d
+}
+
+// parseTTL parses a non-negative duration, accepting a bare "0".
+func parseTTL(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// StackSectionEnabled reports whether PR descriptions get a stack section.
+func (c *Config) StackSectionEnabled() bool {
+	return c == nil || c.StackSection == nil || *c.StackSection
+}
+
+// DefaultReviewers returns the reviewers requested on every new PR:
+// STK_REVIEWERS if it's set (an empty value means none), otherwise the
+// configured list.
+func (c *Config) DefaultReviewers() []string {
+	if env, ok := os.LookupEnv(ReviewersEnv); ok {
+		var reviewers []string
+		for _, r := range strings.Split(env, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				reviewers = append(reviewers, r)
+			}
+		}
+		return reviewers
+	}
+	if c == nil {
+		return nil
+	}
+	return c.Reviewers
+}
+
+// PrefixBranch applies BranchPrefix to a branch name unless the name
+// already contains a slash.
+func (c *Config) PrefixBranch(name string) string {
+	if c == nil || c.BranchPrefix == "" || strings.Contains(name, "/") {
+		return name
+	}
+	return c.BranchPrefix + name
+}
+
+// Path returns the config file path for a repository root.
+func Path(repoRoot string) string {
+	return filepath.Join(repoRoot, FileName)
+}
+
+// Scaffold renders a config file listing every supported key with its
+// default value, preceded by the key's doc tag as a comment.
+func Scaffold() []byte {
+	var b strings.Builder
+	b.WriteString("# stk repository settings. Every key is optional; the values below are\n")
+	b.WriteString("# the defaults.\n")
+
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		b.WriteString("\n")
+		if doc := field.Tag.Get("doc"); doc != "" {
+			for _, line := range strings.Split(doc, "\n") {
+				fmt.Fprintf(&b, "# %s\n", line)
+			}
+		}
+
+		value := field.Tag.Get("default")
+		if value == "" {
+			switch field.Type.Kind() {
+			case reflect.Bool:
+				value = "false"
+			case reflect.Slice:
+				value = "[]"
+			default:
+				value = `""`
+			}
+		}
+		fmt.Fprintf(&b, "%s: %s\n", key, value)
+	}
+	return []byte(b.String())
+}
+
+// Load reads the config file from the repository root.
+// A missing file yields an empty config.
+func Load(repoRoot string) (*Config, error) {
+	cfg := &Config{}
+
+	data, err := os.ReadFile(Path(repoRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FileName, err)
+	}
+	var legacy struct {
+		Token string `yaml:"token"`
+	}
+	if err := yaml.Unmarshal(data, &legacy); err == nil && legacy.Token != "" {
+		cfg.IgnoredToken = true
+	}
+	if cfg.PRCacheTTL != "" {
+		if _, err := parseTTL(cfg.PRCacheTTL); err != nil {
+			return nil, fmt.Errorf("pr_cache_ttl in %s: %w", FileName, err)
+		}
+	}
+
+	return cfg, nil
+}
@@ -22,6 +22,11 @@ func (g *Git) CreateAndCheckout(name string) error {
 	return g.Run("checkout", "-b", name)
 }
 
+// CommitEmpty creates a commit with no changes on the current branch.
+func (g *Git) CommitEmpty(message string) error {
+	return g.Run("commit", "--allow-empty", "-m", message)
+}
+
 // DeleteBranch deletes a branch.
 func (g *Git) DeleteBranch(name string, force bool) error {
 	flag := "-d"
@@ -31,6 +36,12 @@ func (g *Git) DeleteBranch(name string, force bool) error {
 	return g.Run("branch", flag, name)
 }
 
+// DeleteRemoteTrackingBranch deletes the local remote-tracking ref
+// <remote>/<name>, e.g. after the branch was deleted on the remote.
+func (g *Git) DeleteRemoteTrackingBranch(remote, name string) error {
+	return g.RunSilent("branch", "-d", "-r", remote+"/"+name)
+}
+
 // RenameBranch renames a branch.
 func (g *Git) RenameBranch(oldName, newName string) error {
 	return g.Run("branch", "-m", oldName, newName)
@@ -41,6 +52,12 @@ func (g *Git) ResetHard(ref string) error {
 	return g.Run("reset", "--hard", ref)
 }
 
+// MergeFFOnly fast-forwards the current branch to ref, failing if that
+// isn't possible.
+func (g *Git) MergeFFOnly(ref string) error {
+	return g.Run("merge", "--ff-only", ref)
+}
+
 // ResetHardSilent resets without output.
 func (g *Git) ResetHardSilent(ref string) error {
 	return g.RunSilent("reset", "--hard", ref)
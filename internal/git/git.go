@@ -8,26 +8,70 @@ import (
 	"strings"
 )
 
+// BinaryEnv names the environment variable that overrides the git binary.
+const BinaryEnv = "STK_GIT"
+
+// DefaultBinary returns the git executable new instances run: $STK_GIT if
+// set, else "git" looked up on PATH.
+func DefaultBinary() string {
+	if bin := os.Getenv(BinaryEnv); bin != "" {
+		return bin
+	}
+	return "git"
+}
+
 // Git provides methods for executing git commands.
 type Git struct {
 	// WorkDir is the working directory for git commands.
 	// If empty, uses the current directory.
 	WorkDir string
+
+	// Binary is the git executable to run. Bare names are looked up on
+	// PATH; empty means "git".
+	Binary string
+
+	// CacheStatus memoizes 'git status' output until the next command run
+	// through Run or RunSilent, or an explicit InvalidateStatus call.
+	// Useful within a single CLI invocation on large working trees.
+	CacheStatus bool
+
+	status *string // cached 'git status --porcelain' output
 }
 
 // New creates a new Git instance.
 func New() *Git {
-	return &Git{}
+	return &Git{Binary: DefaultBinary()}
 }
 
 // NewWithWorkDir creates a new Git instance with a specific working directory.
 func NewWithWorkDir(workDir string) *Git {
-	return &Git{WorkDir: workDir}
+	return &Git{WorkDir: workDir, Binary: DefaultBinary()}
+}
+
+// bin returns the git executable to run.
+func (g *Git) bin() string {
+	if g.Binary == "" {
+		return "git"
+	}
+	return g.Binary
+}
+
+// CheckAvailable returns an error if the git executable can't be found.
+func (g *Git) CheckAvailable() error {
+	bin := g.bin()
+	if _, err := exec.LookPath(bin); err != nil {
+		if bin != "git" {
+			return fmt.Errorf("git not found at %s; point %s or git_binary in .stk.yaml at a git executable", bin, BinaryEnv)
+		}
+		return fmt.Errorf("git not found on PATH; install git or set %s to its location", BinaryEnv)
+	}
+	return nil
 }
 
 // Run executes a git command with output to stdout/stderr.
 func (g *Git) Run(args ...string) error {
-	cmd := exec.Command("git", args...)
+	g.InvalidateStatus()
+	cmd := exec.Command(g.bin(), args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
@@ -39,7 +83,8 @@ func (g *Git) Run(args ...string) error {
 
 // RunSilent executes a git command without output.
 func (g *Git) RunSilent(args ...string) error {
-	cmd := exec.Command("git", args...)
+	g.InvalidateStatus()
+	cmd := exec.Command(g.bin(), args...)
 	if g.WorkDir != "" {
 		cmd.Dir = g.WorkDir
 	}
@@ -48,7 +93,7 @@ func (g *Git) RunSilent(args ...string) error {
 
 // Output executes a git command and returns the output.
 func (g *Git) Output(args ...string) (string, error) {
-	cmd := exec.Command("git", args...)
+	cmd := exec.Command(g.bin(), args...)
 	if g.WorkDir != "" {
 		cmd.Dir = g.WorkDir
 	}
@@ -95,13 +140,35 @@ func (g *Git) IsInsideWorkTree() bool {
 
 // IsClean returns true if the working tree is clean.
 func (g *Git) IsClean() (bool, error) {
-	out, err := g.Output("status", "--porcelain")
+	out, err := g.statusPorcelain()
 	if err != nil {
 		return false, err
 	}
 	return len(strings.TrimSpace(out)) == 0, nil
 }
 
+// statusPorcelain returns 'git status --porcelain' output, served from the
+// cache when CacheStatus is set.
+func (g *Git) statusPorcelain() (string, error) {
+	if g.CacheStatus && g.status != nil {
+		return *g.status, nil
+	}
+	out, err := g.Output("status", "--porcelain")
+	if err != nil {
+		return "", err
+	}
+	if g.CacheStatus {
+		g.status = &out
+	}
+	return out, nil
+}
+
+// InvalidateStatus drops the cached status so the next check runs git again.
+// Call it after changing the working tree outside of Run and RunSilent.
+func (g *Git) InvalidateStatus() {
+	g.status = nil
+}
+
 // EnsureClean returns an error if the working tree is not clean.
 func (g *Git) EnsureClean() error {
 	clean, err := g.IsClean()
@@ -114,6 +181,42 @@ func (g *Git) EnsureClean() error {
 	return nil
 }
 
+// DirtySubmodules returns the paths of submodules whose checked-out commit
+// or content differs from what the superproject records. Submodule ignore
+// settings are overridden so nothing is hidden.
+func (g *Git) DirtySubmodules() ([]string, error) {
+	out, err := g.Output("status", "--porcelain=v2", "--ignore-submodules=none")
+	if err != nil {
+		return nil, err
+	}
+
+	var dirty []string
+	for _, line := range strings.Split(out, "\n") {
+		// Ordinary changed entries: "1 XY sub mH mI mW hH hI path"
+		fields := strings.Fields(line)
+		if len(fields) < 9 || fields[0] != "1" {
+			continue
+		}
+		// sub is "N..." for regular files and "S<c><m><u>" for submodules
+		if strings.HasPrefix(fields[2], "S") {
+			dirty = append(dirty, strings.Join(fields[8:], " "))
+		}
+	}
+	return dirty, nil
+}
+
+// EnsureCleanSubmodules returns an error naming any dirty submodules.
+func (g *Git) EnsureCleanSubmodules() error {
+	dirty, err := g.DirtySubmodules()
+	if err != nil {
+		return fmt.Errorf("failed to check submodule status: %w", err)
+	}
+	if len(dirty) > 0 {
+		return fmt.Errorf("submodule(s) have uncommitted changes: %s; commit or reset them first", strings.Join(dirty, ", "))
+	}
+	return nil
+}
+
 // CurrentBranch returns the name of the current branch.
 func (g *Git) CurrentBranch() (string, error) {
 	return g.OutputTrim("branch", "--show-current")
@@ -159,6 +262,11 @@ func (g *Git) SHA(ref string) (string, error) {
 	return g.OutputTrim("rev-parse", ref)
 }
 
+// CommitExists reports whether sha names a commit in the local repository.
+func (g *Git) CommitExists(sha string) bool {
+	return g.RunSilent("cat-file", "-e", sha+"^{commit}") == nil
+}
+
 // ShortSHA returns the short commit SHA for a ref.
 func (g *Git) ShortSHA(ref string) (string, error) {
 	return g.OutputTrim("rev-parse", "--short", ref)
@@ -187,17 +295,62 @@ func (g *Git) CommitCount(base, head string) (int, error) {
 	return count, nil
 }
 
+// FirstCommitSubject returns the subject line of the oldest commit in
+// base..head, or an empty string when there are none.
+func (g *Git) FirstCommitSubject(base, head string) (string, error) {
+	subjects, err := g.OutputLines("log", "--reverse", "--format=%s", base+".."+head)
+	if err != nil || len(subjects) == 0 {
+		return "", err
+	}
+	return subjects[0], nil
+}
+
+// DiffShortStat returns the 'git diff --shortstat' summary between two refs,
+// or an empty string when they don't differ.
+func (g *Git) DiffShortStat(base, head string) (string, error) {
+	return g.OutputTrim("diff", "--shortstat", base+".."+head)
+}
+
+// DiffNameOnly returns the paths of files changed between two refs.
+func (g *Git) DiffNameOnly(base, head string) ([]string, error) {
+	return g.OutputLines("diff", "--name-only", base+".."+head)
+}
+
 // MergeBase returns the merge base of two refs.
 func (g *Git) MergeBase(a, b string) (string, error) {
 	return g.OutputTrim("merge-base", a, b)
 }
 
+// ForkPoint returns the commit where branch forked from upstream, using
+// upstream's reflog to see past rewrites of upstream (see
+// 'git merge-base --fork-point').
+func (g *Git) ForkPoint(upstream, branch string) (string, error) {
+	return g.OutputTrim("merge-base", "--fork-point", upstream, branch)
+}
+
 // IsAncestor returns true if a is an ancestor of b.
 func (g *Git) IsAncestor(a, b string) bool {
 	err := g.RunSilent("merge-base", "--is-ancestor", a, b)
 	return err == nil
 }
 
+// ConfigBool returns the boolean value of a git config key.
+// Missing or invalid keys are treated as false.
+func (g *Git) ConfigBool(key string) bool {
+	out, err := g.OutputTrim("config", "--bool", key)
+	return err == nil && out == "true"
+}
+
+// ConfigString returns the value of a git config key, or an empty string
+// if it isn't set.
+func (g *Git) ConfigString(key string) string {
+	out, err := g.OutputTrim("config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
 // Remote returns the URL for a remote.
 func (g *Git) Remote(name string) (string, error) {
 	return g.OutputTrim("remote", "get-url", name)
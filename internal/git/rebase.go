@@ -1,6 +1,11 @@
 package git
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
 
 // RebaseResult represents the outcome of a rebase operation.
 type RebaseResult struct {
@@ -9,9 +14,11 @@ type RebaseResult struct {
 	Message     string
 }
 
-// Rebase rebases the current branch onto a target.
-func (g *Git) Rebase(onto string) error {
-	return g.Run("rebase", onto)
+// Rebase rebases the current branch onto a target. Extra arguments are
+// passed to 'git rebase' ahead of the target.
+func (g *Git) Rebase(onto string, args ...string) error {
+	cmdArgs := append(append([]string{"rebase"}, args...), onto)
+	return g.Run(cmdArgs...)
 }
 
 // RebaseOnto rebases using --onto syntax.
@@ -20,8 +27,8 @@ func (g *Git) RebaseOnto(onto, upstream, branch string) error {
 }
 
 // RebaseInteractive starts an interactive rebase.
-func (g *Git) RebaseInteractive(onto string) error {
-	return g.Run("rebase", "-i", onto)
+func (g *Git) RebaseInteractive(onto string, args ...string) error {
+	return g.Rebase(onto, append([]string{"-i"}, args...)...)
 }
 
 // RebaseAbort aborts an in-progress rebase.
@@ -36,26 +43,79 @@ func (g *Git) RebaseContinue() error {
 
 // IsRebaseInProgress checks if a rebase is in progress.
 func (g *Git) IsRebaseInProgress() bool {
-	gitDir, err := g.GitDir()
+	return g.rebaseDir() != ""
+}
+
+// RebaseHeadBranch returns the branch being rebased by an in-progress
+// rebase, or an empty string if there is none or HEAD was detached.
+func (g *Git) RebaseHeadBranch() string {
+	dir := g.rebaseDir()
+	if dir == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "head-name"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(data)), "refs/heads/")
+}
+
+// OperationInProgress returns the git operation that stopped midway and
+// is waiting to be continued or aborted: "merge", "cherry-pick" or
+// "revert". It returns an empty string if there is none. Rebases are
+// reported by IsRebaseInProgress.
+func (g *Git) OperationInProgress() string {
+	gitDir, err := g.OutputTrim("rev-parse", "--absolute-git-dir")
 	if err != nil {
-		return false
+		return ""
 	}
-	// Check for rebase-merge or rebase-apply directories
-	_, err1 := g.Output("ls", gitDir+"/rebase-merge")
-	_, err2 := g.Output("ls", gitDir+"/rebase-apply")
-	return err1 == nil || err2 == nil
+	for _, op := range []struct{ head, name string }{
+		{"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+	} {
+		if _, err := os.Stat(filepath.Join(gitDir, op.head)); err == nil {
+			return op.name
+		}
+	}
+	return ""
+}
+
+// rebaseDir returns the state directory of an in-progress rebase
+// (rebase-merge or rebase-apply), or an empty string if there is none.
+func (g *Git) rebaseDir() string {
+	gitDir, err := g.OutputTrim("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return ""
+	}
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		dir := filepath.Join(gitDir, name)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
 }
 
 // RebaseBranchOnto rebases a branch onto a new base.
-// This is the main operation for stack rebasing.
-func (g *Git) RebaseBranchOnto(branch, onto string) error {
+// This is the main operation for stack rebasing. upstream is the old tip
+// of the branch's parent: only commits after it are replayed, so commits
+// the parent rewrote (e.g. amended) aren't replayed again. With an empty
+// upstream, every commit not in onto is replayed. Extra arguments are
+// passed to 'git rebase' (e.g. "--empty=keep").
+func (g *Git) RebaseBranchOnto(branch, onto, upstream string, args ...string) error {
 	// Checkout the branch
 	if err := g.Checkout(branch); err != nil {
 		return fmt.Errorf("failed to checkout %s: %w", branch, err)
 	}
 
 	// Rebase onto target
-	if err := g.Rebase(onto); err != nil {
+	target := onto
+	if upstream != "" {
+		args = append(args, "--onto", onto)
+		target = upstream
+	}
+	if err := g.Rebase(target, args...); err != nil {
 		return fmt.Errorf("rebase of %s onto %s failed: %w", branch, onto, err)
 	}
 
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReflogEntry is a single entry in a branch's reflog.
+type ReflogEntry struct {
+	Branch  string
+	Time    time.Time
+	SHA     string // abbreviated
+	Message string
+}
+
+// Reflog returns the reflog entries for a local branch, newest first.
+func (g *Git) Reflog(branch string) ([]ReflogEntry, error) {
+	lines, err := g.OutputLines("reflog", "show", "--date=unix",
+		"--format=%gd%x09%h%x09%gs", "refs/heads/"+branch, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ReflogEntry
+	for _, line := range lines {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		// Selector looks like branch@{1700000000}
+		selector := parts[0]
+		open := strings.LastIndex(selector, "@{")
+		if open < 0 || !strings.HasSuffix(selector, "}") {
+			continue
+		}
+		secs, err := strconv.ParseInt(selector[open+2:len(selector)-1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, ReflogEntry{
+			Branch:  branch,
+			Time:    time.Unix(secs, 0),
+			SHA:     parts[1],
+			Message: parts[2],
+		})
+	}
+	return entries, nil
+}
@@ -1,5 +1,12 @@
 package git
 
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
 // Fetch fetches from a remote.
 func (g *Git) Fetch(remote string, args ...string) error {
 	cmdArgs := append([]string{"fetch", remote}, args...)
@@ -26,6 +33,17 @@ func (g *Git) Push(remote, branch string, force bool) error {
 	return g.Run(args...)
 }
 
+// PushMany pushes several branches to a remote in one invocation, sharing
+// a single connection and ref negotiation. Pushes aren't atomic, so some
+// branches may have been updated when it fails.
+func (g *Git) PushMany(remote string, branches []string, force bool) error {
+	args := append([]string{"push", "-u", remote}, branches...)
+	if force {
+		args = append(args, "--force-with-lease")
+	}
+	return g.Run(args...)
+}
+
 // PushSilent pushes without output.
 func (g *Git) PushSilent(remote, branch string, force bool) error {
 	args := []string{"push", "-u", remote, branch}
@@ -35,6 +53,23 @@ func (g *Git) PushSilent(remote, branch string, force bool) error {
 	return g.RunSilent(args...)
 }
 
+// PushQuiet pushes without output and without setting the upstream, so it
+// can run alongside other pushes: setting upstreams writes .git/config,
+// which concurrent pushes would contend for. Git's error output is returned
+// in the error.
+func (g *Git) PushQuiet(remote, branch string, force bool) error {
+	args := []string{"push", remote, branch}
+	if force {
+		args = append(args, "--force-with-lease")
+	}
+	_, err := g.Output(args...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
+
 // PushDelete deletes a remote branch.
 func (g *Git) PushDelete(remote, branch string) error {
 	return g.Run("push", remote, "--delete", branch)
@@ -0,0 +1,47 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Stash saves uncommitted changes, including untracked files, to a new
+// stash entry with the given message and returns the entry's commit SHA.
+func (g *Git) Stash(message string) (string, error) {
+	if err := g.RunSilent("stash", "push", "--include-untracked", "-m", message); err != nil {
+		return "", err
+	}
+	return g.OutputTrim("rev-parse", "refs/stash")
+}
+
+// stashIndex returns the position of the stash entry with the given commit
+// SHA in 'git stash list', or -1 if it isn't there.
+func (g *Git) stashIndex(sha string) int {
+	out, err := g.OutputTrim("stash", "list", "--format=%H")
+	if err != nil || out == "" {
+		return -1
+	}
+	for i, entry := range strings.Split(out, "\n") {
+		if entry == sha {
+			return i
+		}
+	}
+	return -1
+}
+
+// HasStash reports whether the stash entry with the given commit SHA still
+// exists.
+func (g *Git) HasStash(sha string) bool {
+	return g.stashIndex(sha) >= 0
+}
+
+// StashPop applies the stash entry with the given commit SHA to the working
+// tree and drops it. Like 'git stash pop', it keeps the entry if applying
+// it conflicts.
+func (g *Git) StashPop(sha string) error {
+	i := g.stashIndex(sha)
+	if i < 0 {
+		return fmt.Errorf("stash %s not found", sha)
+	}
+	return g.RunSilent("stash", "pop", fmt.Sprintf("stash@{%d}", i))
+}
@@ -0,0 +1,139 @@
+package pr
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CodeOwnersPaths are the locations searched for a CODEOWNERS file,
+// relative to the repository root, in GitHub's order of precedence.
+var CodeOwnersPaths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// CodeOwners maps file paths to their owners.
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// LoadCodeOwners reads the first CODEOWNERS file found under repoRoot and
+// returns it along with its path. It returns os.ErrNotExist if there is none.
+func LoadCodeOwners(repoRoot string) (*CodeOwners, string, error) {
+	for _, rel := range CodeOwnersPaths {
+		path := filepath.Join(repoRoot, rel)
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, path, err
+		}
+		defer f.Close()
+
+		co := &CodeOwners{}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			// Skip comments and GitLab section headers
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+				continue
+			}
+			if i := strings.Index(line, " #"); i >= 0 {
+				line = line[:i]
+			}
+			fields := strings.Fields(line)
+			co.rules = append(co.rules, codeOwnersRule{
+				pattern: compileCodeOwnersPattern(fields[0]),
+				owners:  fields[1:],
+			})
+		}
+		return co, path, scanner.Err()
+	}
+	return nil, "", os.ErrNotExist
+}
+
+// Owners returns the users owning any of the given files, without the
+// leading "@". As in CODEOWNERS, the last matching rule for a file wins.
+// Teams ("@org/team") and email addresses are left out, since they can't
+// be requested as reviewers by login.
+func (c *CodeOwners) Owners(files []string) []string {
+	seen := make(map[string]bool)
+	var owners []string
+	for _, file := range files {
+		var matched []string
+		for _, rule := range c.rules {
+			if rule.pattern.MatchString(file) {
+				matched = rule.owners
+			}
+		}
+		for _, owner := range matched {
+			if !strings.HasPrefix(owner, "@") || strings.Contains(owner, "/") {
+				continue
+			}
+			login := strings.TrimPrefix(owner, "@")
+			if !seen[login] {
+				seen[login] = true
+				owners = append(owners, login)
+			}
+		}
+	}
+	return owners
+}
+
+// compileCodeOwnersPattern turns a gitignore-style CODEOWNERS pattern into
+// a regexp matching repository-relative file paths. A pattern that names a
+// directory, with a trailing slash or a last segment without wildcards,
+// also matches everything below it; "dir/*" only matches dir's direct
+// children.
+func compileCodeOwnersPattern(pattern string) *regexp.Regexp {
+	// Patterns with a leading or inner slash are relative to the root;
+	// others match at any depth
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+		case pattern[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	last := pattern[strings.LastIndex(pattern, "/")+1:]
+	switch {
+	case dirOnly:
+		sb.WriteString("/.*$")
+	case strings.ContainsAny(last, "*?"):
+		sb.WriteString("$")
+	default:
+		sb.WriteString("(?:/.*)?$")
+	}
+	return regexp.MustCompile(sb.String())
+}
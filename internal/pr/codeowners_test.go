@@ -0,0 +1,41 @@
+package pr
+
+import "testing"
+
+func TestCompileCodeOwnersPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		// dir/* covers direct children only
+		{"docs/*", "docs/intro.md", true},
+		{"docs/*", "docs/guides/setup.md", false},
+		{"docs/*", "other/docs/intro.md", false},
+
+		// dir/ covers the whole tree, at any depth
+		{"docs/", "docs/intro.md", true},
+		{"docs/", "docs/guides/setup.md", true},
+		{"docs/", "src/docs/intro.md", true},
+		{"docs/", "docs", false},
+
+		// A name without wildcards may be a file or a directory
+		{"docs", "docs", true},
+		{"docs", "docs/guides/setup.md", true},
+		{"/build/logs", "build/logs/today.log", true},
+		{"/build/logs", "src/build/logs/today.log", false},
+
+		// Wildcards in the last segment match file names
+		{"*.go", "main.go", true},
+		{"*.go", "internal/pr/github.go", true},
+		{"*.go", "main.go.orig", false},
+		{"internal/**", "internal/pr/github.go", true},
+		{"**/logs", "deep/down/logs/today.log", true},
+	}
+
+	for _, tt := range tests {
+		if got := compileCodeOwnersPattern(tt.pattern).MatchString(tt.path); got != tt.want {
+			t.Errorf("pattern %q on %q = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package pr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Error classes for provider failures. Use errors.Is to check which class
+// an error returned by a Provider belongs to.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+
+	// ErrHeadNotFound means the provider hasn't registered a just-pushed
+	// head branch yet. Retrying shortly usually succeeds.
+	ErrHeadNotFound = errors.New("head branch not found")
+
+	// ErrMergeQueue means the target branch only accepts merges through a
+	// merge queue. Enqueue the PR with MergeOptions.Queue instead.
+	ErrMergeQueue = errors.New("merge queue required")
+
+	// ErrPRExists means Create found a pull request for the head branch
+	// already (typically a closed one, since open PRs are found by
+	// GetByBranch beforehand).
+	ErrPRExists = errors.New("a pull request already exists for this branch")
+
+	// ErrIncomplete means Create opened the pull request but couldn't set
+	// everything asked for (e.g. its labels). The PR is returned with it.
+	ErrIncomplete = errors.New("pull request created incompletely")
+)
+
+// APIError is returned when a provider API call fails.
+type APIError struct {
+	Provider   string // GitHub, GitLab
+	StatusCode int    // HTTP status code, 0 if no request was made
+	Message    string
+	Err        error // error class (ErrNotFound, ...), nil if unclassified
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the error class so errors.Is works against it.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// newAPIError builds an APIError from an unsuccessful HTTP response,
+// classifying it by status code.
+func newAPIError(provider string, code int, body []byte) *APIError {
+	msg := fmt.Sprintf("%s API error: %d %s", provider, code, http.StatusText(code))
+	if len(body) > 0 {
+		msg += " - " + string(body)
+	}
+	return &APIError{
+		Provider:   provider,
+		StatusCode: code,
+		Message:    msg,
+		Err:        classifyStatus(code, body),
+	}
+}
+
+// apiErrorf builds an APIError with a custom message and explicit class.
+func apiErrorf(provider string, code int, class error, format string, args ...interface{}) *APIError {
+	return &APIError{
+		Provider:   provider,
+		StatusCode: code,
+		Message:    fmt.Sprintf(format, args...),
+		Err:        class,
+	}
+}
+
+// classifyStatus maps an HTTP status code to an error class.
+func classifyStatus(code int, body []byte) error {
+	switch code {
+	case 401:
+		return ErrUnauthorized
+	case 403:
+		// GitHub reports exhausted rate limits as 403
+		if strings.Contains(strings.ToLower(string(body)), "rate limit") {
+			return ErrRateLimited
+		}
+		return ErrUnauthorized
+	case 404:
+		return ErrNotFound
+	case 409:
+		return ErrConflict
+	case 429:
+		return ErrRateLimited
+	}
+	return nil
+}
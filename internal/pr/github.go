@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 )
 
 // GitHubProvider implements the Provider interface for GitHub.
@@ -16,6 +17,14 @@ type GitHubProvider struct {
 	Token string
 	Owner string
 	Repo  string
+
+	// HeadOwner is the owner of the fork that branches are pushed to when
+	// it isn't the PR repository. Empty means Owner.
+	HeadOwner string
+
+	// The token is looked up once, even when PRs are created concurrently
+	tokenOnce sync.Once
+	tokenErr  error
 }
 
 // Name returns "github".
@@ -28,6 +37,26 @@ func (g *GitHubProvider) Detect(remoteURL string) bool {
 	return strings.Contains(remoteURL, "github.com")
 }
 
+// SupportsDraft returns true; GitHub has native draft PRs.
+func (g *GitHubProvider) SupportsDraft() bool {
+	return true
+}
+
+// SupportsLabels returns true.
+func (g *GitHubProvider) SupportsLabels() bool {
+	return true
+}
+
+// SupportsReviewers returns true.
+func (g *GitHubProvider) SupportsReviewers() bool {
+	return true
+}
+
+// SupportsTeamReviewers returns true.
+func (g *GitHubProvider) SupportsTeamReviewers() bool {
+	return true
+}
+
 // SetRepo sets the owner and repo from a remote URL.
 func (g *GitHubProvider) SetRepo(remoteURL string) error {
 	owner, repo, err := ParseRemoteURL(remoteURL)
@@ -39,16 +68,28 @@ func (g *GitHubProvider) SetRepo(remoteURL string) error {
 	return nil
 }
 
-// getToken retrieves the GitHub token from environment or gh CLI.
+// URL returns the web URL of a pull request.
+func (g *GitHubProvider) URL(number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", g.Owner, g.Repo, number)
+}
+
+// getToken retrieves the GitHub token from environment or gh CLI. It's
+// resolved on first use and safe to call from several goroutines.
 func (g *GitHubProvider) getToken() (string, error) {
+	g.tokenOnce.Do(g.resolveToken)
+	return g.Token, g.tokenErr
+}
+
+// resolveToken fills in Token if it isn't set yet.
+func (g *GitHubProvider) resolveToken() {
 	if g.Token != "" {
-		return g.Token, nil
+		return
 	}
 
 	// Check environment variable
 	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
 		g.Token = token
-		return token, nil
+		return
 	}
 
 	// Try gh CLI
@@ -56,10 +97,26 @@ func (g *GitHubProvider) getToken() (string, error) {
 	out, err := cmd.Output()
 	if err == nil {
 		g.Token = strings.TrimSpace(string(out))
-		return g.Token, nil
+		return
 	}
 
-	return "", fmt.Errorf("no GitHub token found; set GITHUB_TOKEN or login with 'gh auth login'")
+	g.tokenErr = apiErrorf("GitHub", 0, ErrUnauthorized, "no GitHub token found; set GITHUB_TOKEN or login with 'gh auth login'")
+}
+
+// headRef returns the head reference for a branch, prefixed with the fork
+// owner for cross-repository PRs.
+func headRef(owner, branch string) string {
+	if owner == "" {
+		return branch
+	}
+	return owner + ":" + branch
+}
+
+// isHeadNotFound reports whether a 422 response says the head branch or its
+// SHA is unknown, which happens when the PR is created right after a push.
+func isHeadNotFound(body []byte) bool {
+	msg := strings.ToLower(string(body))
+	return strings.Contains(msg, `"field":"head"`) || strings.Contains(msg, "head sha")
 }
 
 // Create creates a new pull request on GitHub.
@@ -72,9 +129,9 @@ func (g *GitHubProvider) Create(opts CreateOptions) (*PR, error) {
 	// Build request body
 	body := map[string]interface{}{
 		"title": opts.Title,
-		"head":  opts.Head,
+		"head":  headRef(opts.HeadOwner, opts.Head),
 		"base":  opts.Base,
-		"body":  opts.Body,
+		"body":  createBody(opts),
 		"draft": opts.Draft,
 	}
 
@@ -105,8 +162,17 @@ func (g *GitHubProvider) Create(opts CreateOptions) (*PR, error) {
 
 	respBody, _ := io.ReadAll(resp.Body)
 
+	if resp.StatusCode == 422 && strings.Contains(string(respBody), "already exists") {
+		return nil, apiErrorf("GitHub", resp.StatusCode, ErrPRExists,
+			"GitHub API error: a pull request for %s already exists - %s", headRef(opts.HeadOwner, opts.Head), string(respBody))
+	}
+	if resp.StatusCode == 422 && isHeadNotFound(respBody) {
+		return nil, apiErrorf("GitHub", resp.StatusCode, ErrHeadNotFound,
+			"GitHub API error: head branch %s not found - %s", headRef(opts.HeadOwner, opts.Head), string(respBody))
+	}
+
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(respBody))
+		return nil, newAPIError("GitHub", resp.StatusCode, respBody)
 	}
 
 	// Parse response
@@ -127,14 +193,56 @@ func (g *GitHubProvider) Create(opts CreateOptions) (*PR, error) {
 		state = "draft"
 	}
 
-	return &PR{
+	created := &PR{
 		Number: result.Number,
 		URL:    result.HTMLURL,
 		State:  state,
 		Title:  result.Title,
 		Head:   opts.Head,
 		Base:   opts.Base,
-	}, nil
+	}
+
+	// Labels and milestones can't be set at creation; set them through the
+	// issues API. The PR exists either way, so it's returned along with
+	// any failure.
+	if len(opts.Labels) > 0 {
+		if err := g.addLabels(result.Number, opts.Labels); err != nil {
+			return created, fmt.Errorf("%w: failed to add labels to PR #%d: %w", ErrIncomplete, result.Number, err)
+		}
+	}
+	if opts.Milestone != 0 {
+		if err := g.setMilestone(result.Number, opts.Milestone); err != nil {
+			return created, fmt.Errorf("%w: failed to set the milestone of PR #%d: %w", ErrIncomplete, result.Number, err)
+		}
+	}
+
+	return created, nil
+}
+
+// setMilestone sets the milestone of a pull request through the issues API.
+func (g *GitHubProvider) setMilestone(number, milestone int) error {
+	status, respBody, err := g.request("PATCH", fmt.Sprintf("/issues/%d", number),
+		map[string]interface{}{"milestone": milestone})
+	if err != nil {
+		return err
+	}
+	if status != 200 {
+		return newAPIError("GitHub", status, respBody)
+	}
+	return nil
+}
+
+// addLabels adds labels to a pull request through the issues API.
+func (g *GitHubProvider) addLabels(number int, labels []string) error {
+	status, respBody, err := g.request("POST", fmt.Sprintf("/issues/%d/labels", number),
+		map[string]interface{}{"labels": labels})
+	if err != nil {
+		return err
+	}
+	if status != 200 {
+		return newAPIError("GitHub", status, respBody)
+	}
+	return nil
 }
 
 // Get retrieves a pull request by number.
@@ -162,11 +270,11 @@ func (g *GitHubProvider) Get(number int) (*PR, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("PR #%d not found", number)
+		return nil, apiErrorf("GitHub", 404, ErrNotFound, "PR #%d not found", number)
 	}
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitHub API error: %s", resp.Status)
+		return nil, newAPIError("GitHub", resp.StatusCode, nil)
 	}
 
 	var result struct {
@@ -174,6 +282,7 @@ func (g *GitHubProvider) Get(number int) (*PR, error) {
 		HTMLURL string `json:"html_url"`
 		State   string `json:"state"`
 		Title   string `json:"title"`
+		Body    string `json:"body"`
 		Draft   bool   `json:"draft"`
 		Head    struct {
 			Ref string `json:"ref"`
@@ -181,6 +290,9 @@ func (g *GitHubProvider) Get(number int) (*PR, error) {
 		Base struct {
 			Ref string `json:"ref"`
 		} `json:"base"`
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
 		Merged bool `json:"merged"`
 	}
 
@@ -201,11 +313,47 @@ func (g *GitHubProvider) Get(number int) (*PR, error) {
 		URL:    result.HTMLURL,
 		State:  state,
 		Title:  result.Title,
+		Body:   result.Body,
 		Head:   result.Head.Ref,
 		Base:   result.Base.Ref,
+		Author: result.User.Login,
 	}, nil
 }
 
+// GetDiff returns a pull request's diff, using the diff media type.
+func (g *GitHubProvider) GetDiff(number int) (string, error) {
+	token, err := g.getToken()
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", g.Owner, g.Repo, number)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github.diff")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode == 404 {
+		return "", apiErrorf("GitHub", 404, ErrNotFound, "PR #%d not found", number)
+	}
+	if resp.StatusCode != 200 {
+		return "", newAPIError("GitHub", resp.StatusCode, respBody)
+	}
+	return string(respBody), nil
+}
+
 // GetByBranch retrieves a pull request for a given head branch.
 func (g *GitHubProvider) GetByBranch(branch string) (*PR, error) {
 	token, err := g.getToken()
@@ -213,8 +361,13 @@ func (g *GitHubProvider) GetByBranch(branch string) (*PR, error) {
 		return nil, err
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?head=%s:%s&state=open",
-		g.Owner, g.Repo, g.Owner, branch)
+	headOwner := g.HeadOwner
+	if headOwner == "" {
+		headOwner = g.Owner
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?head=%s&state=open",
+		g.Owner, g.Repo, headRef(headOwner, branch))
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -232,7 +385,7 @@ func (g *GitHubProvider) GetByBranch(branch string) (*PR, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("GitHub API error: %s", resp.Status)
+		return nil, newAPIError("GitHub", resp.StatusCode, nil)
 	}
 
 	var results []struct {
@@ -240,6 +393,7 @@ func (g *GitHubProvider) GetByBranch(branch string) (*PR, error) {
 		HTMLURL string `json:"html_url"`
 		State   string `json:"state"`
 		Title   string `json:"title"`
+		Body    string `json:"body"`
 		Draft   bool   `json:"draft"`
 		Head    struct {
 			Ref string `json:"ref"`
@@ -247,6 +401,9 @@ func (g *GitHubProvider) GetByBranch(branch string) (*PR, error) {
 		Base struct {
 			Ref string `json:"ref"`
 		} `json:"base"`
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
 	}
 
 	respBody, _ := io.ReadAll(resp.Body)
@@ -269,8 +426,10 @@ func (g *GitHubProvider) GetByBranch(branch string) (*PR, error) {
 		URL:    result.HTMLURL,
 		State:  state,
 		Title:  result.Title,
+		Body:   result.Body,
 		Head:   result.Head.Ref,
 		Base:   result.Base.Ref,
+		Author: result.User.Login,
 	}, nil
 }
 
@@ -310,7 +469,7 @@ func (g *GitHubProvider) Retarget(number int, newBase string) error {
 
 	if resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(respBody))
+		return newAPIError("GitHub", resp.StatusCode, respBody)
 	}
 
 	return nil
@@ -363,7 +522,7 @@ func (g *GitHubProvider) Update(number int, opts UpdateOptions) error {
 
 	if resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(respBody))
+		return newAPIError("GitHub", resp.StatusCode, respBody)
 	}
 
 	return nil
@@ -376,10 +535,17 @@ func (g *GitHubProvider) Close(number int) error {
 }
 
 // Merge merges a pull request.
-func (g *GitHubProvider) Merge(number int, opts MergeOptions) error {
+func (g *GitHubProvider) Merge(number int, opts MergeOptions) (string, error) {
+	if opts.Queue {
+		return "", g.enqueue(number)
+	}
+	if opts.Auto {
+		return "", g.enableAutoMerge(number, opts.Method)
+	}
+
 	token, err := g.getToken()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	body := make(map[string]interface{})
@@ -400,13 +566,13 @@ func (g *GitHubProvider) Merge(number int, opts MergeOptions) error {
 
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d/merge", g.Owner, g.Repo, number)
 	req, err := http.NewRequest("PUT", url, bytes.NewReader(jsonBody))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
@@ -417,34 +583,159 @@ func (g *GitHubProvider) Merge(number int, opts MergeOptions) error {
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return "", fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 405 {
-		return fmt.Errorf("PR cannot be merged (not mergeable or requires review)")
+		respBody, _ := io.ReadAll(resp.Body)
+		if strings.Contains(strings.ToLower(string(respBody)), "merge queue") {
+			return "", apiErrorf("GitHub", 405, ErrMergeQueue, "PR #%d must be merged through the merge queue", number)
+		}
+		return "", apiErrorf("GitHub", 405, nil, "PR cannot be merged (not mergeable or requires review)")
 	}
 
 	if resp.StatusCode == 409 {
-		return fmt.Errorf("PR has conflicts that must be resolved")
+		return "", apiErrorf("GitHub", 409, ErrConflict, "PR has conflicts that must be resolved")
 	}
 
+	respBody, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(respBody))
+		return "", newAPIError("GitHub", resp.StatusCode, respBody)
 	}
 
-	return nil
+	var result struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.SHA, nil
+}
+
+// enqueue adds a pull request to its base branch's merge queue. Merge
+// queues are only exposed through the GraphQL API.
+func (g *GitHubProvider) enqueue(number int) error {
+	id, err := g.nodeID(number)
+	if err != nil {
+		return err
+	}
+
+	_, err = g.graphql(`mutation($id: ID!) {
+  enqueuePullRequest(input: {pullRequestId: $id}) { mergeQueueEntry { position } }
+}`, map[string]interface{}{"id": id})
+	return err
+}
+
+// enableAutoMerge turns on auto-merge so the PR merges once its required
+// checks pass. Like merge queues, this is GraphQL-only.
+func (g *GitHubProvider) enableAutoMerge(number int, method string) error {
+	id, err := g.nodeID(number)
+	if err != nil {
+		return err
+	}
+
+	mergeMethod := "MERGE"
+	switch method {
+	case "squash":
+		mergeMethod = "SQUASH"
+	case "rebase":
+		mergeMethod = "REBASE"
+	}
+
+	_, err = g.graphql(`mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId }
+}`, map[string]interface{}{"id": id, "method": mergeMethod})
+	return err
+}
+
+// nodeID returns the GraphQL node ID of a pull request.
+func (g *GitHubProvider) nodeID(number int) (string, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/pulls/%d", number), nil)
+	if err != nil {
+		return "", err
+	}
+	if status == 404 {
+		return "", apiErrorf("GitHub", 404, ErrNotFound, "PR #%d not found", number)
+	}
+	if status != 200 {
+		return "", newAPIError("GitHub", status, respBody)
+	}
+
+	var pull struct {
+		NodeID string `json:"node_id"`
+	}
+	if err := json.Unmarshal(respBody, &pull); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return pull.NodeID, nil
+}
+
+// graphql runs a GraphQL query and returns its data field.
+func (g *GitHubProvider) graphql(query string, variables map[string]interface{}) (json.RawMessage, error) {
+	token, err := g.getToken()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBody, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return nil, newAPIError("GitHub", resp.StatusCode, respBody)
+	}
+
+	// GraphQL reports failures in the body with a 200 status
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return nil, apiErrorf("GitHub", resp.StatusCode, nil, "GitHub API error: %s", result.Errors[0].Message)
+	}
+	return result.Data, nil
 }
 
-// DeleteBranch deletes a branch on GitHub.
+// DeleteBranch deletes a branch on GitHub, from the fork when HeadOwner
+// is set.
 func (g *GitHubProvider) DeleteBranch(branch string) error {
 	token, err := g.getToken()
 	if err != nil {
 		return err
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/refs/heads/%s", g.Owner, g.Repo, branch)
+	owner := g.HeadOwner
+	if owner == "" {
+		owner = g.Owner
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/git/refs/heads/%s", owner, g.Repo, branch)
 	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -463,8 +754,356 @@ func (g *GitHubProvider) DeleteBranch(branch string) error {
 
 	if resp.StatusCode != 204 && resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitHub API error: %s - %s", resp.Status, string(respBody))
+		// GitHub answers 422 for refs that are already gone
+		if resp.StatusCode == 422 && strings.Contains(string(respBody), "Reference does not exist") {
+			return apiErrorf("GitHub", 422, ErrNotFound, "branch %s not found", branch)
+		}
+		return newAPIError("GitHub", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// request sends an authenticated request to the GitHub repo API and returns
+// the status code and response body. path is relative to the repo endpoint.
+func (g *GitHubProvider) request(method, path string, payload interface{}) (int, []byte, error) {
+	token, err := g.getToken()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var reader io.Reader
+	if payload != nil {
+		jsonBody, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	url := fmt.Sprintf("https://api.github.com/repos/%s/%s%s", g.Owner, g.Repo, path)
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, respBody, nil
+}
+
+// reviewRequestBody builds a requested_reviewers body, sending entries
+// with TeamReviewerPrefix as team slugs (an "org/" before the slug is
+// dropped, since teams always belong to the repository's organization).
+func reviewRequestBody(reviewers []string) map[string]interface{} {
+	users, teams := []string{}, []string{}
+	for _, r := range reviewers {
+		if team, ok := strings.CutPrefix(r, TeamReviewerPrefix); ok {
+			if i := strings.LastIndex(team, "/"); i >= 0 {
+				team = team[i+1:]
+			}
+			teams = append(teams, team)
+			continue
+		}
+		users = append(users, r)
+	}
+	return map[string]interface{}{"reviewers": users, "team_reviewers": teams}
+}
+
+// AddReviewers requests reviews from the given users and teams.
+func (g *GitHubProvider) AddReviewers(number int, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	status, respBody, err := g.request("POST", fmt.Sprintf("/pulls/%d/requested_reviewers", number),
+		reviewRequestBody(reviewers))
+	if err != nil {
+		return err
+	}
+	if status != 201 {
+		return newAPIError("GitHub", status, respBody)
+	}
+	return nil
+}
+
+// RemoveReviewers withdraws review requests from the given users and teams.
+func (g *GitHubProvider) RemoveReviewers(number int, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
 	}
 
+	status, respBody, err := g.request("DELETE", fmt.Sprintf("/pulls/%d/requested_reviewers", number),
+		reviewRequestBody(reviewers))
+	if err != nil {
+		return err
+	}
+	if status != 200 {
+		return newAPIError("GitHub", status, respBody)
+	}
 	return nil
 }
+
+// AddAssignees assigns the given users to a pull request.
+func (g *GitHubProvider) AddAssignees(number int, assignees []string) error {
+	if len(assignees) == 0 {
+		return nil
+	}
+
+	// Assignees are managed through the issues API
+	status, respBody, err := g.request("POST", fmt.Sprintf("/issues/%d/assignees", number),
+		map[string]interface{}{"assignees": assignees})
+	if err != nil {
+		return err
+	}
+	if status != 201 {
+		return newAPIError("GitHub", status, respBody)
+	}
+	return nil
+}
+
+// FindMilestone resolves an open milestone title to its number.
+func (g *GitHubProvider) FindMilestone(title string) (int, error) {
+	status, respBody, err := g.request("GET", "/milestones?state=open&per_page=100", nil)
+	if err != nil {
+		return 0, err
+	}
+	if status != 200 {
+		return 0, newAPIError("GitHub", status, respBody)
+	}
+
+	var milestones []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	if err := json.Unmarshal(respBody, &milestones); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	for _, m := range milestones {
+		if m.Title == title {
+			return m.Number, nil
+		}
+	}
+	return 0, apiErrorf("GitHub", 404, ErrNotFound, "milestone %q not found", title)
+}
+
+// DefaultBranch returns the repository's default branch.
+func (g *GitHubProvider) DefaultBranch() (string, error) {
+	status, respBody, err := g.request("GET", "", nil)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 {
+		return "", newAPIError("GitHub", status, respBody)
+	}
+
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(respBody, &repo); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if repo.DefaultBranch == "" {
+		return "", fmt.Errorf("GitHub did not report a default branch for %s/%s", g.Owner, g.Repo)
+	}
+	return repo.DefaultBranch, nil
+}
+
+// CurrentUser returns the login of the authenticated user.
+func (g *GitHubProvider) CurrentUser() (string, error) {
+	token, err := g.getToken()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest("GET", "https://api.github.com/user", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return "", newAPIError("GitHub", resp.StatusCode, respBody)
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(respBody, &user); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return user.Login, nil
+}
+
+// GetLabels returns the labels of a pull request.
+func (g *GitHubProvider) GetLabels(number int) ([]string, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/issues/%d/labels", number), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != 200 {
+		return nil, newAPIError("GitHub", status, respBody)
+	}
+
+	var labels []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(respBody, &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	names := make([]string, 0, len(labels))
+	for _, l := range labels {
+		names = append(names, l.Name)
+	}
+	return names, nil
+}
+
+// Mergeable reports whether a pull request merges cleanly. GitHub computes
+// this in the background, so a fresh PR may still report MergeComputing.
+func (g *GitHubProvider) Mergeable(number int) (bool, string, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/pulls/%d", number), nil)
+	if err != nil {
+		return false, "", err
+	}
+	if status == 404 {
+		return false, "", apiErrorf("GitHub", 404, ErrNotFound, "PR #%d not found", number)
+	}
+	if status != 200 {
+		return false, "", newAPIError("GitHub", status, respBody)
+	}
+
+	var pull struct {
+		Mergeable      *bool  `json:"mergeable"`
+		MergeableState string `json:"mergeable_state"`
+	}
+	if err := json.Unmarshal(respBody, &pull); err != nil {
+		return false, "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	switch {
+	case pull.Mergeable == nil || pull.MergeableState == "unknown":
+		return false, MergeComputing, nil
+	case *pull.Mergeable:
+		return true, "", nil
+	case pull.MergeableState == "dirty":
+		return false, MergeConflicts, nil
+	default:
+		return false, pull.MergeableState, nil
+	}
+}
+
+// Checks returns the combined status of check runs and commit statuses on
+// the head commit of a pull request.
+func (g *GitHubProvider) Checks(number int) (CheckStatus, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/pulls/%d", number), nil)
+	if err != nil {
+		return "", err
+	}
+	if status == 404 {
+		return "", apiErrorf("GitHub", 404, ErrNotFound, "PR #%d not found", number)
+	}
+	if status != 200 {
+		return "", newAPIError("GitHub", status, respBody)
+	}
+
+	var pull struct {
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+	}
+	if err := json.Unmarshal(respBody, &pull); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Check runs (GitHub Actions and apps)
+	status, respBody, err = g.request("GET", fmt.Sprintf("/commits/%s/check-runs?per_page=100", pull.Head.SHA), nil)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 {
+		return "", newAPIError("GitHub", status, respBody)
+	}
+
+	var runs struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := json.Unmarshal(respBody, &runs); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Commit statuses (external CI)
+	status, respBody, err = g.request("GET", fmt.Sprintf("/commits/%s/status", pull.Head.SHA), nil)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 {
+		return "", newAPIError("GitHub", status, respBody)
+	}
+
+	var combined struct {
+		Statuses []struct {
+			State string `json:"state"`
+		} `json:"statuses"`
+	}
+	if err := json.Unmarshal(respBody, &combined); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(runs.CheckRuns) == 0 && len(combined.Statuses) == 0 {
+		return ChecksNotConfigured, nil
+	}
+
+	pending := false
+	for _, r := range runs.CheckRuns {
+		if r.Status != "completed" {
+			pending = true
+			continue
+		}
+		switch r.Conclusion {
+		case "failure", "cancelled", "timed_out", "action_required":
+			return ChecksFailing, nil
+		}
+	}
+	for _, st := range combined.Statuses {
+		switch st.State {
+		case "failure", "error":
+			return ChecksFailing, nil
+		case "pending":
+			pending = true
+		}
+	}
+
+	if pending {
+		return ChecksPending, nil
+	}
+	return ChecksPassing, nil
+}
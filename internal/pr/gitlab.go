@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 )
 
 // GitLabProvider implements the Provider interface for GitLab.
@@ -17,6 +18,14 @@ type GitLabProvider struct {
 	Token   string
 	BaseURL string // e.g., "https://gitlab.com" or self-hosted instance
 	Project string // URL-encoded project path (e.g., "owner%2Frepo")
+
+	// HeadOwner is the namespace of the fork that branches are pushed to
+	// when it isn't the MR project. Empty means Project.
+	HeadOwner string
+
+	// The token is looked up once, even when MRs are created concurrently
+	tokenOnce sync.Once
+	tokenErr  error
 }
 
 // Name returns "gitlab".
@@ -30,6 +39,27 @@ func (g *GitLabProvider) Detect(remoteURL string) bool {
 		strings.Contains(remoteURL, "gitlab.")
 }
 
+// SupportsDraft returns false: drafts are marked with a "Draft:" title
+// prefix rather than a flag.
+func (g *GitLabProvider) SupportsDraft() bool {
+	return false
+}
+
+// SupportsLabels returns true.
+func (g *GitLabProvider) SupportsLabels() bool {
+	return true
+}
+
+// SupportsReviewers returns true.
+func (g *GitLabProvider) SupportsReviewers() bool {
+	return true
+}
+
+// SupportsTeamReviewers returns false: GitLab has no team reviewers.
+func (g *GitLabProvider) SupportsTeamReviewers() bool {
+	return false
+}
+
 // SetRepo sets the project path and base URL from a remote URL.
 func (g *GitLabProvider) SetRepo(remoteURL string) error {
 	// Parse SSH URL: git@gitlab.com:owner/repo.git
@@ -61,22 +91,29 @@ func (g *GitLabProvider) SetRepo(remoteURL string) error {
 	return fmt.Errorf("unrecognized URL format: %s", remoteURL)
 }
 
-// getToken retrieves the GitLab token from environment or glab CLI.
+// getToken retrieves the GitLab token from environment or glab CLI. It's
+// resolved on first use and safe to call from several goroutines.
 func (g *GitLabProvider) getToken() (string, error) {
+	g.tokenOnce.Do(g.resolveToken)
+	return g.Token, g.tokenErr
+}
+
+// resolveToken fills in Token if it isn't set yet.
+func (g *GitLabProvider) resolveToken() {
 	if g.Token != "" {
-		return g.Token, nil
+		return
 	}
 
 	// Check environment variable
 	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
 		g.Token = token
-		return token, nil
+		return
 	}
 
 	// Also check GITLAB_PRIVATE_TOKEN (common alternative)
 	if token := os.Getenv("GITLAB_PRIVATE_TOKEN"); token != "" {
 		g.Token = token
-		return token, nil
+		return
 	}
 
 	// Try glab CLI (GitLab CLI tool)
@@ -84,10 +121,10 @@ func (g *GitLabProvider) getToken() (string, error) {
 	out, err := cmd.Output()
 	if err == nil {
 		g.Token = strings.TrimSpace(string(out))
-		return g.Token, nil
+		return
 	}
 
-	return "", fmt.Errorf("no GitLab token found; set GITLAB_TOKEN or login with 'glab auth login'")
+	g.tokenErr = apiErrorf("GitLab", 0, ErrUnauthorized, "no GitLab token found; set GITLAB_TOKEN or login with 'glab auth login'")
 }
 
 // getBaseURL returns the base URL for the GitLab API.
@@ -98,6 +135,15 @@ func (g *GitLabProvider) getBaseURL() string {
 	return g.BaseURL
 }
 
+// URL returns the web URL of a merge request.
+func (g *GitLabProvider) URL(number int) string {
+	project, err := url.PathUnescape(g.Project)
+	if err != nil {
+		project = g.Project
+	}
+	return fmt.Sprintf("%s/%s/-/merge_requests/%d", g.getBaseURL(), project, number)
+}
+
 // Create creates a new merge request on GitLab.
 func (g *GitLabProvider) Create(opts CreateOptions) (*PR, error) {
 	token, err := g.getToken()
@@ -110,7 +156,7 @@ func (g *GitLabProvider) Create(opts CreateOptions) (*PR, error) {
 		"title":         opts.Title,
 		"source_branch": opts.Head,
 		"target_branch": opts.Base,
-		"description":   opts.Body,
+		"description":   createBody(opts),
 	}
 
 	// GitLab doesn't have draft as a simple boolean, but uses WIP prefix or draft flag
@@ -126,13 +172,29 @@ func (g *GitLabProvider) Create(opts CreateOptions) (*PR, error) {
 		body["labels"] = strings.Join(opts.Labels, ",")
 	}
 
+	if opts.Milestone != 0 {
+		body["milestone_id"] = opts.Milestone
+	}
+
+	// Merge requests from a fork are opened on the fork's project and
+	// point back at this one through target_project_id
+	project := g.Project
+	if opts.HeadOwner != "" {
+		id, err := g.projectID(g.Project)
+		if err != nil {
+			return nil, err
+		}
+		body["target_project_id"] = id
+		project = g.forkProject(opts.HeadOwner)
+	}
+
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Create request
-	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests", g.getBaseURL(), g.Project)
+	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests", g.getBaseURL(), project)
 	req, err := http.NewRequest("POST", apiURL, bytes.NewReader(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -151,8 +213,13 @@ func (g *GitLabProvider) Create(opts CreateOptions) (*PR, error) {
 
 	respBody, _ := io.ReadAll(resp.Body)
 
+	if resp.StatusCode == 409 && strings.Contains(string(respBody), "already exists") {
+		return nil, apiErrorf("GitLab", resp.StatusCode, ErrPRExists,
+			"GitLab API error: a merge request for %s already exists - %s", opts.Head, string(respBody))
+	}
+
 	if resp.StatusCode != 201 {
-		return nil, fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(respBody))
+		return nil, newAPIError("GitLab", resp.StatusCode, respBody)
 	}
 
 	// Parse response
@@ -183,6 +250,36 @@ func (g *GitLabProvider) Create(opts CreateOptions) (*PR, error) {
 	}, nil
 }
 
+// forkProject returns the URL-encoded path of the project's fork under the
+// given namespace, assuming the fork kept the project name.
+func (g *GitLabProvider) forkProject(namespace string) string {
+	path, err := url.PathUnescape(g.Project)
+	if err != nil {
+		path = g.Project
+	}
+	name := path[strings.LastIndex(path, "/")+1:]
+	return url.PathEscape(namespace + "/" + name)
+}
+
+// projectID resolves a URL-encoded project path to its numeric ID.
+func (g *GitLabProvider) projectID(project string) (int, error) {
+	status, respBody, err := g.request("GET", "/projects/"+project, nil)
+	if err != nil {
+		return 0, err
+	}
+	if status != 200 {
+		return 0, newAPIError("GitLab", status, respBody)
+	}
+
+	var result struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.ID, nil
+}
+
 // mapState converts GitLab state to unified state.
 func (g *GitLabProvider) mapState(state string, isDraft bool) string {
 	switch state {
@@ -223,12 +320,12 @@ func (g *GitLabProvider) Get(number int) (*PR, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 404 {
-		return nil, fmt.Errorf("MR !%d not found", number)
+		return nil, apiErrorf("GitLab", 404, ErrNotFound, "MR !%d not found", number)
 	}
 
 	if resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(respBody))
+		return nil, newAPIError("GitLab", resp.StatusCode, respBody)
 	}
 
 	var result struct {
@@ -241,6 +338,9 @@ func (g *GitLabProvider) Get(number int) (*PR, error) {
 		TargetBranch   string `json:"target_branch"`
 		Draft          bool   `json:"draft"`
 		WorkInProgress bool   `json:"work_in_progress"`
+		Author         struct {
+			Username string `json:"username"`
+		} `json:"author"`
 	}
 
 	respBody, _ := io.ReadAll(resp.Body)
@@ -258,9 +358,67 @@ func (g *GitLabProvider) Get(number int) (*PR, error) {
 		Body:   result.Description,
 		Head:   result.SourceBranch,
 		Base:   result.TargetBranch,
+		Author: result.Author.Username,
 	}, nil
 }
 
+// GetDiff returns a merge request's diff, assembled from the per-file
+// diffs of the changes endpoint.
+func (g *GitLabProvider) GetDiff(number int) (string, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/projects/%s/merge_requests/%d/changes", g.Project, number), nil)
+	if err != nil {
+		return "", err
+	}
+	if status == 404 {
+		return "", apiErrorf("GitLab", 404, ErrNotFound, "MR !%d not found", number)
+	}
+	if status != 200 {
+		return "", newAPIError("GitLab", status, respBody)
+	}
+
+	var result struct {
+		Changes []struct {
+			OldPath     string `json:"old_path"`
+			NewPath     string `json:"new_path"`
+			Diff        string `json:"diff"`
+			NewFile     bool   `json:"new_file"`
+			DeletedFile bool   `json:"deleted_file"`
+			RenamedFile bool   `json:"renamed_file"`
+			AMode       string `json:"a_mode"`
+			BMode       string `json:"b_mode"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// The per-file diffs only hold hunks; add the headers git would print
+	var sb strings.Builder
+	for _, c := range result.Changes {
+		fmt.Fprintf(&sb, "diff --git a/%s b/%s\n", c.OldPath, c.NewPath)
+		oldName, newName := "a/"+c.OldPath, "b/"+c.NewPath
+		switch {
+		case c.NewFile:
+			fmt.Fprintf(&sb, "new file mode %s\n", c.BMode)
+			oldName = "/dev/null"
+		case c.DeletedFile:
+			fmt.Fprintf(&sb, "deleted file mode %s\n", c.AMode)
+			newName = "/dev/null"
+		case c.RenamedFile:
+			fmt.Fprintf(&sb, "rename from %s\nrename to %s\n", c.OldPath, c.NewPath)
+		}
+		if c.Diff == "" {
+			continue
+		}
+		fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+		sb.WriteString(c.Diff)
+		if !strings.HasSuffix(c.Diff, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
+
 // GetByBranch retrieves a merge request for a given source branch.
 func (g *GitLabProvider) GetByBranch(branch string) (*PR, error) {
 	token, err := g.getToken()
@@ -286,7 +444,7 @@ func (g *GitLabProvider) GetByBranch(branch string) (*PR, error) {
 
 	if resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(respBody))
+		return nil, newAPIError("GitLab", resp.StatusCode, respBody)
 	}
 
 	var results []struct {
@@ -299,6 +457,9 @@ func (g *GitLabProvider) GetByBranch(branch string) (*PR, error) {
 		TargetBranch   string `json:"target_branch"`
 		Draft          bool   `json:"draft"`
 		WorkInProgress bool   `json:"work_in_progress"`
+		Author         struct {
+			Username string `json:"username"`
+		} `json:"author"`
 	}
 
 	respBody, _ := io.ReadAll(resp.Body)
@@ -321,6 +482,7 @@ func (g *GitLabProvider) GetByBranch(branch string) (*PR, error) {
 		Body:   result.Description,
 		Head:   result.SourceBranch,
 		Base:   result.TargetBranch,
+		Author: result.Author.Username,
 	}, nil
 }
 
@@ -358,7 +520,7 @@ func (g *GitLabProvider) Retarget(number int, newBase string) error {
 
 	if resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(respBody))
+		return newAPIError("GitLab", resp.StatusCode, respBody)
 	}
 
 	return nil
@@ -415,7 +577,7 @@ func (g *GitLabProvider) Update(number int, opts UpdateOptions) error {
 
 	if resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(respBody))
+		return newAPIError("GitLab", resp.StatusCode, respBody)
 	}
 
 	return nil
@@ -428,10 +590,14 @@ func (g *GitLabProvider) Close(number int) error {
 }
 
 // Merge merges a merge request.
-func (g *GitLabProvider) Merge(number int, opts MergeOptions) error {
+func (g *GitLabProvider) Merge(number int, opts MergeOptions) (string, error) {
+	if opts.Queue {
+		return "", fmt.Errorf("merge queues are not supported for GitLab; merge trains are configured per project")
+	}
+
 	token, err := g.getToken()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	body := make(map[string]interface{})
@@ -445,7 +611,10 @@ func (g *GitLabProvider) Merge(number int, opts MergeOptions) error {
 	case "rebase":
 		// GitLab handles this through merge request settings, not API
 		// The merge will use fast-forward if possible when rebase is set in project settings
-		body["merge_when_pipeline_succeeds"] = false
+	}
+
+	if opts.Auto {
+		body["merge_when_pipeline_succeeds"] = true
 	}
 
 	if opts.CommitMsg != "" {
@@ -466,13 +635,13 @@ func (g *GitLabProvider) Merge(number int, opts MergeOptions) error {
 
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/merge_requests/%d/merge", g.getBaseURL(), g.Project, number)
 	req, err := http.NewRequest("PUT", apiURL, bytes.NewReader(jsonBody))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("PRIVATE-TOKEN", token)
@@ -481,39 +650,56 @@ func (g *GitLabProvider) Merge(number int, opts MergeOptions) error {
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return "", fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == 405 {
-		return fmt.Errorf("MR cannot be merged (not mergeable, requires approval, or has conflicts)")
+		return "", apiErrorf("GitLab", 405, nil, "MR cannot be merged (not mergeable, requires approval, or has conflicts)")
 	}
 
 	if resp.StatusCode == 406 {
-		return fmt.Errorf("MR has conflicts that must be resolved")
+		return "", apiErrorf("GitLab", 406, ErrConflict, "MR has conflicts that must be resolved")
 	}
 
 	if resp.StatusCode == 401 {
-		return fmt.Errorf("unauthorized: check your GitLab token permissions")
+		return "", apiErrorf("GitLab", 401, ErrUnauthorized, "unauthorized: check your GitLab token permissions")
 	}
 
+	respBody, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode != 200 {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(respBody))
+		return "", newAPIError("GitLab", resp.StatusCode, respBody)
 	}
 
-	return nil
+	// Until the pipeline succeeds there is no merge commit yet
+	var mr struct {
+		MergeCommitSHA  string `json:"merge_commit_sha"`
+		SquashCommitSHA string `json:"squash_commit_sha"`
+	}
+	if err := json.Unmarshal(respBody, &mr); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if mr.MergeCommitSHA != "" {
+		return mr.MergeCommitSHA, nil
+	}
+	return mr.SquashCommitSHA, nil
 }
 
-// DeleteBranch deletes a branch on GitLab.
+// DeleteBranch deletes a branch on GitLab, from the fork when HeadOwner
+// is set.
 func (g *GitLabProvider) DeleteBranch(branch string) error {
 	token, err := g.getToken()
 	if err != nil {
 		return err
 	}
 
+	project := g.Project
+	if g.HeadOwner != "" {
+		project = g.forkProject(g.HeadOwner)
+	}
+
 	apiURL := fmt.Sprintf("%s/api/v4/projects/%s/repository/branches/%s",
-		g.getBaseURL(), g.Project, url.PathEscape(branch))
+		g.getBaseURL(), project, url.PathEscape(branch))
 	req, err := http.NewRequest("DELETE", apiURL, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -530,8 +716,365 @@ func (g *GitLabProvider) DeleteBranch(branch string) error {
 
 	if resp.StatusCode != 204 && resp.StatusCode != 200 {
 		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("GitLab API error: %s - %s", resp.Status, string(respBody))
+		return newAPIError("GitLab", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// request sends an authenticated request to the GitLab API and returns the
+// status code and response body. path is relative to /api/v4.
+func (g *GitLabProvider) request(method, path string, payload interface{}) (int, []byte, error) {
+	token, err := g.getToken()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var reader io.Reader
+	if payload != nil {
+		jsonBody, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	apiURL := g.getBaseURL() + "/api/v4" + path
+	req, err := http.NewRequest(method, apiURL, reader)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, respBody, nil
+}
+
+// resolveUserIDs maps GitLab usernames to user IDs.
+func (g *GitLabProvider) resolveUserIDs(usernames []string) ([]int, error) {
+	var ids []int
+	for _, name := range usernames {
+		name = strings.TrimPrefix(name, "@")
+		status, respBody, err := g.request("GET", "/users?username="+url.QueryEscape(name), nil)
+		if err != nil {
+			return nil, err
+		}
+		if status != 200 {
+			return nil, newAPIError("GitLab", status, respBody)
+		}
+
+		var users []struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(respBody, &users); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("GitLab user %q not found", name)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// getParticipantIDs returns the current reviewer and assignee IDs of a merge request.
+func (g *GitLabProvider) getParticipantIDs(number int) (reviewers, assignees []int, err error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/projects/%s/merge_requests/%d", g.Project, number), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if status == 404 {
+		return nil, nil, apiErrorf("GitLab", 404, ErrNotFound, "MR !%d not found", number)
+	}
+	if status != 200 {
+		return nil, nil, newAPIError("GitLab", status, respBody)
+	}
+
+	var result struct {
+		Reviewers []struct {
+			ID int `json:"id"`
+		} `json:"reviewers"`
+		Assignees []struct {
+			ID int `json:"id"`
+		} `json:"assignees"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	reviewers = []int{}
+	for _, r := range result.Reviewers {
+		reviewers = append(reviewers, r.ID)
 	}
+	assignees = []int{}
+	for _, a := range result.Assignees {
+		assignees = append(assignees, a.ID)
+	}
+	return reviewers, assignees, nil
+}
 
+// setParticipantIDs replaces the reviewer or assignee list of a merge request.
+// field is either "reviewer_ids" or "assignee_ids".
+func (g *GitLabProvider) setParticipantIDs(number int, field string, ids []int) error {
+	status, respBody, err := g.request("PUT", fmt.Sprintf("/projects/%s/merge_requests/%d", g.Project, number),
+		map[string]interface{}{field: ids})
+	if err != nil {
+		return err
+	}
+	if status != 200 {
+		return newAPIError("GitLab", status, respBody)
+	}
 	return nil
 }
+
+// mergeIDs returns current with any IDs from add that aren't already present.
+func mergeIDs(current, add []int) []int {
+	result := append([]int{}, current...)
+	for _, id := range add {
+		found := false
+		for _, c := range current {
+			if c == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// AddReviewers adds the given users as reviewers of a merge request.
+func (g *GitLabProvider) AddReviewers(number int, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	ids, err := g.resolveUserIDs(reviewers)
+	if err != nil {
+		return err
+	}
+	current, _, err := g.getParticipantIDs(number)
+	if err != nil {
+		return err
+	}
+	return g.setParticipantIDs(number, "reviewer_ids", mergeIDs(current, ids))
+}
+
+// RemoveReviewers removes the given users from the reviewers of a merge request.
+func (g *GitLabProvider) RemoveReviewers(number int, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	ids, err := g.resolveUserIDs(reviewers)
+	if err != nil {
+		return err
+	}
+	current, _, err := g.getParticipantIDs(number)
+	if err != nil {
+		return err
+	}
+
+	remove := make(map[int]bool)
+	for _, id := range ids {
+		remove[id] = true
+	}
+	remaining := []int{}
+	for _, id := range current {
+		if !remove[id] {
+			remaining = append(remaining, id)
+		}
+	}
+	return g.setParticipantIDs(number, "reviewer_ids", remaining)
+}
+
+// AddAssignees adds the given users as assignees of a merge request.
+func (g *GitLabProvider) AddAssignees(number int, assignees []string) error {
+	if len(assignees) == 0 {
+		return nil
+	}
+
+	ids, err := g.resolveUserIDs(assignees)
+	if err != nil {
+		return err
+	}
+	_, current, err := g.getParticipantIDs(number)
+	if err != nil {
+		return err
+	}
+	return g.setParticipantIDs(number, "assignee_ids", mergeIDs(current, ids))
+}
+
+// FindMilestone resolves an active project milestone title to its ID.
+func (g *GitLabProvider) FindMilestone(title string) (int, error) {
+	status, respBody, err := g.request("GET",
+		fmt.Sprintf("/projects/%s/milestones?state=active&title=%s", g.Project, url.QueryEscape(title)), nil)
+	if err != nil {
+		return 0, err
+	}
+	if status != 200 {
+		return 0, newAPIError("GitLab", status, respBody)
+	}
+
+	var milestones []struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	}
+	if err := json.Unmarshal(respBody, &milestones); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	for _, m := range milestones {
+		if m.Title == title {
+			return m.ID, nil
+		}
+	}
+	return 0, apiErrorf("GitLab", 404, ErrNotFound, "milestone %q not found", title)
+}
+
+// DefaultBranch returns the project's default branch.
+func (g *GitLabProvider) DefaultBranch() (string, error) {
+	status, respBody, err := g.request("GET", "/projects/"+g.Project, nil)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 {
+		return "", newAPIError("GitLab", status, respBody)
+	}
+
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(respBody, &project); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if project.DefaultBranch == "" {
+		return "", fmt.Errorf("GitLab did not report a default branch (empty repository?)")
+	}
+	return project.DefaultBranch, nil
+}
+
+// CurrentUser returns the username of the authenticated user.
+func (g *GitLabProvider) CurrentUser() (string, error) {
+	status, respBody, err := g.request("GET", "/user", nil)
+	if err != nil {
+		return "", err
+	}
+	if status != 200 {
+		return "", newAPIError("GitLab", status, respBody)
+	}
+
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := json.Unmarshal(respBody, &user); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return user.Username, nil
+}
+
+// GetLabels returns the labels of a merge request.
+func (g *GitLabProvider) GetLabels(number int) ([]string, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/projects/%s/merge_requests/%d", g.Project, number), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == 404 {
+		return nil, apiErrorf("GitLab", 404, ErrNotFound, "MR !%d not found", number)
+	}
+	if status != 200 {
+		return nil, newAPIError("GitLab", status, respBody)
+	}
+
+	var result struct {
+		Labels []string `json:"labels"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.Labels, nil
+}
+
+// Mergeable reports whether a merge request merges cleanly, based on its
+// merge_status, which GitLab recomputes in the background.
+func (g *GitLabProvider) Mergeable(number int) (bool, string, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/projects/%s/merge_requests/%d", g.Project, number), nil)
+	if err != nil {
+		return false, "", err
+	}
+	if status == 404 {
+		return false, "", apiErrorf("GitLab", 404, ErrNotFound, "MR !%d not found", number)
+	}
+	if status != 200 {
+		return false, "", newAPIError("GitLab", status, respBody)
+	}
+
+	var result struct {
+		MergeStatus  string `json:"merge_status"`
+		HasConflicts bool   `json:"has_conflicts"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return false, "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	switch {
+	case result.HasConflicts:
+		return false, MergeConflicts, nil
+	case result.MergeStatus == "can_be_merged":
+		return true, "", nil
+	case result.MergeStatus == "cannot_be_merged":
+		return false, MergeConflicts, nil
+	default: // unchecked, checking, cannot_be_merged_recheck
+		return false, MergeComputing, nil
+	}
+}
+
+// Checks returns the status of the head pipeline of a merge request.
+// Projects without CI have no head pipeline and report ChecksNotConfigured.
+func (g *GitLabProvider) Checks(number int) (CheckStatus, error) {
+	status, respBody, err := g.request("GET", fmt.Sprintf("/projects/%s/merge_requests/%d", g.Project, number), nil)
+	if err != nil {
+		return "", err
+	}
+	if status == 404 {
+		return "", apiErrorf("GitLab", 404, ErrNotFound, "MR !%d not found", number)
+	}
+	if status != 200 {
+		return "", newAPIError("GitLab", status, respBody)
+	}
+
+	var result struct {
+		HeadPipeline *struct {
+			Status string `json:"status"`
+		} `json:"head_pipeline"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.HeadPipeline == nil {
+		return ChecksNotConfigured, nil
+	}
+
+	switch result.HeadPipeline.Status {
+	case "success", "skipped":
+		return ChecksPassing, nil
+	case "failed", "canceled":
+		return ChecksFailing, nil
+	default:
+		return ChecksPending, nil
+	}
+}
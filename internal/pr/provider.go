@@ -2,10 +2,16 @@
 package pr
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"text/template"
 )
 
+// ErrUnsupportedRemote is returned by DetectProvider when no provider
+// handles the remote.
+var ErrUnsupportedRemote = errors.New("unsupported remote")
+
 // Provider defines the interface for PR platforms.
 type Provider interface {
 	// Name returns the provider name (github, gitlab, etc.)
@@ -14,7 +20,22 @@ type Provider interface {
 	// Detect checks if this provider can be used for the given remote URL.
 	Detect(remoteURL string) bool
 
-	// Create creates a new pull request.
+	// SupportsDraft reports whether the platform has native draft PRs.
+	SupportsDraft() bool
+
+	// SupportsLabels reports whether labels can be set on PRs.
+	SupportsLabels() bool
+
+	// SupportsReviewers reports whether reviews can be requested on PRs.
+	SupportsReviewers() bool
+
+	// SupportsTeamReviewers reports whether reviews can be requested from
+	// teams, given with TeamReviewerPrefix.
+	SupportsTeamReviewers() bool
+
+	// Create creates a new pull request. If the PR is opened but setting,
+	// say, its labels fails, it's returned with an error wrapping
+	// ErrIncomplete.
 	Create(opts CreateOptions) (*PR, error)
 
 	// Update updates an existing pull request.
@@ -26,14 +47,58 @@ type Provider interface {
 	// GetByBranch retrieves a pull request for a given branch.
 	GetByBranch(branch string) (*PR, error)
 
+	// GetDiff returns the pull request's diff against its base, as
+	// computed by the platform, in unified diff format.
+	GetDiff(number int) (string, error)
+
 	// Retarget changes the base branch of a PR.
 	Retarget(number int, newBase string) error
 
 	// Close closes a pull request without merging.
 	Close(number int) error
 
-	// Merge merges a pull request.
-	Merge(number int, opts MergeOptions) error
+	// Merge merges a pull request and returns the SHA of the resulting
+	// merge (or squash) commit. The SHA is empty when the merge is deferred,
+	// e.g. with MergeOptions.Queue or Auto.
+	Merge(number int, opts MergeOptions) (string, error)
+
+	// DeleteBranch deletes a branch from the repository the head
+	// branches are pushed to, which is the fork when there is one.
+	DeleteBranch(branch string) error
+
+	// AddReviewers requests reviews from the given users.
+	AddReviewers(number int, reviewers []string) error
+
+	// RemoveReviewers withdraws review requests from the given users.
+	RemoveReviewers(number int, reviewers []string) error
+
+	// AddAssignees assigns the given users to a pull request.
+	AddAssignees(number int, assignees []string) error
+
+	// Checks returns the combined CI status of a pull request.
+	// Returns ChecksNotConfigured rather than an error when there is no CI.
+	Checks(number int) (CheckStatus, error)
+
+	// Mergeable reports whether a pull request merges cleanly into its
+	// base. When it doesn't, reason says why (e.g. MergeConflicts, or
+	// MergeComputing while the provider is still checking).
+	Mergeable(number int) (bool, string, error)
+
+	// GetLabels returns the labels of a pull request.
+	GetLabels(number int) ([]string, error)
+
+	// FindMilestone resolves an open milestone title to its ID.
+	// Returns an error wrapping ErrNotFound if no such milestone exists.
+	FindMilestone(title string) (int, error)
+
+	// DefaultBranch returns the repository's default branch.
+	DefaultBranch() (string, error)
+
+	// CurrentUser returns the username of the authenticated user.
+	CurrentUser() (string, error)
+
+	// URL returns the web URL of a pull request, without an API call.
+	URL(number int) string
 }
 
 // PR represents a pull request.
@@ -45,8 +110,30 @@ type PR struct {
 	Body   string
 	Head   string // source branch
 	Base   string // target branch
+	Author string // login of the PR's author
 }
 
+// CheckStatus is the combined CI status of a pull request.
+type CheckStatus string
+
+// Check statuses.
+const (
+	ChecksPending       CheckStatus = "pending"
+	ChecksPassing       CheckStatus = "passing"
+	ChecksFailing       CheckStatus = "failing"
+	ChecksNotConfigured CheckStatus = "none" // no CI runs for this PR
+)
+
+// Reasons returned by Provider.Mergeable.
+const (
+	MergeConflicts = "conflicts"
+	MergeComputing = "computing" // the provider hasn't checked yet
+)
+
+// TeamReviewerPrefix marks a reviewer entry as a team rather than a user,
+// e.g. "team:platform", for providers whose SupportsTeamReviewers is true.
+const TeamReviewerPrefix = "team:"
+
 // CreateOptions contains options for creating a PR.
 type CreateOptions struct {
 	Title     string
@@ -56,6 +143,28 @@ type CreateOptions struct {
 	Draft     bool
 	Reviewers []string
 	Labels    []string
+	Milestone int // milestone ID from FindMilestone, 0 for none
+
+	// HeadOwner is the owner of the fork that Head was pushed to, for PRs
+	// opened from a fork. Empty means Head lives in the target repository.
+	HeadOwner string
+
+	// CloseIssues lists issue numbers to close when this PR merges.
+	// They are rendered as closing keywords ahead of Body.
+	CloseIssues []int
+}
+
+// createBody returns the PR body with any closing keywords prepended.
+func createBody(opts CreateOptions) string {
+	if len(opts.CloseIssues) == 0 {
+		return opts.Body
+	}
+
+	var sb strings.Builder
+	for _, n := range opts.CloseIssues {
+		sb.WriteString(fmt.Sprintf("Closes #%d\n", n))
+	}
+	return sb.String() + opts.Body
 }
 
 // UpdateOptions contains options for updating a PR.
@@ -71,6 +180,14 @@ type MergeOptions struct {
 	CommitTitle  string
 	CommitMsg    string
 	DeleteBranch bool
+
+	// Queue adds the PR to the target branch's merge queue instead of
+	// merging it directly. The PR merges once the queue lands it.
+	Queue bool
+
+	// Auto enables auto-merge: the PR merges once its required checks
+	// (or pipeline) pass, instead of right away.
+	Auto bool
 }
 
 // DetectProvider detects the appropriate provider for a remote URL.
@@ -87,7 +204,7 @@ func DetectProvider(remoteURL string) (Provider, error) {
 		return gl, nil
 	}
 
-	return nil, fmt.Errorf("unsupported remote: %s", remoteURL)
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedRemote, remoteURL)
 }
 
 // ParseRemoteURL extracts owner and repo from a remote URL.
@@ -122,51 +239,127 @@ func ParseRemoteURL(remoteURL string) (owner, repo string, err error) {
 	return "", "", fmt.Errorf("unrecognized URL format: %s", remoteURL)
 }
 
+// DefaultStackSectionTemplate is the built-in layout of the stack section.
+const DefaultStackSectionTemplate = `
+---
+
+## 📚 Stack
+
+This PR is part of the **{{.StackName}}** stack:
+
+| # | Branch | PR | Status |
+|---|--------|-----|--------|
+{{range .Branches}}{{if .IsCurrent}}| **{{.Index}}** | **` + "`{{.Name}}`" + `** | **{{.PRRef}}** | **🔄 This PR** |
+{{else}}| {{.Index}} | ` + "`{{.Name}}`" + ` | {{.PRRef}} | {{.Status}} |
+{{end}}{{end}}
+---
+*Managed by [stk](https://github.com/stefanaki/stk)*
+`
+
+// StackSectionData is the data passed to the stack section template.
+type StackSectionData struct {
+	StackName string
+	Current   string
+	Branches  []StackSectionBranch
+}
+
+// StackSectionBranch describes one branch in the stack section template.
+type StackSectionBranch struct {
+	Index     int // 1-based position in the stack
+	Name      string
+	Number    int    // PR number, 0 if none
+	PRRef     string // "#123" or "-"
+	State     string // open, closed, merged, draft, or empty
+	Status    string // human-readable status with emoji
+	IsCurrent bool
+}
+
+// StackSectionMarker precedes the generated stack section in PR bodies.
+// Text above it is written by the user and kept when the section is updated.
+const StackSectionMarker = "<!-- stk:stack -->"
+
+// ComposeBody joins user-written text and a rendered stack section.
+func ComposeBody(text, section string) string {
+	text = strings.TrimRight(text, "\n")
+	if text == "" {
+		return StackSectionMarker + section
+	}
+	return text + "\n\n" + StackSectionMarker + section
+}
+
+// ReplaceStackSection swaps the stack section of an existing PR body for a
+// new one, keeping the text above the marker. Bodies without a marker are
+// replaced entirely.
+func ReplaceStackSection(body, section string) string {
+	idx := strings.Index(body, StackSectionMarker)
+	if idx < 0 {
+		return StackSectionMarker + section
+	}
+	return body[:idx] + StackSectionMarker + section
+}
+
 // GenerateStackSection generates the stack info section for PR body.
 func GenerateStackSection(stackName string, branches []PRBranchInfo, currentBranch string) string {
-	var sb strings.Builder
+	out, err := RenderStackSection(DefaultStackSectionTemplate, stackName, branches, currentBranch)
+	if err != nil {
+		// The default template is static; this only fails on a programming error
+		panic(err)
+	}
+	return out
+}
 
-	sb.WriteString("\n---\n\n")
-	sb.WriteString("## 📚 Stack\n\n")
-	sb.WriteString(fmt.Sprintf("This PR is part of the **%s** stack:\n\n", stackName))
-	sb.WriteString("| # | Branch | PR | Status |\n")
-	sb.WriteString("|---|--------|-----|--------|\n")
+// RenderStackSection renders the stack section using a text/template.
+func RenderStackSection(tmpl, stackName string, branches []PRBranchInfo, currentBranch string) (string, error) {
+	t, err := template.New("stack").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid stack section template: %w", err)
+	}
 
+	data := StackSectionData{
+		StackName: stackName,
+		Current:   currentBranch,
+	}
 	for i, b := range branches {
-		num := fmt.Sprintf("%d", i+1)
-		branch := b.Name
-		pr := "-"
-		status := "📝 Pending"
+		entry := StackSectionBranch{
+			Index:     i + 1,
+			Name:      b.Name,
+			PRRef:     "-",
+			Status:    "📝 Pending",
+			IsCurrent: b.Name == currentBranch,
+		}
 
 		if b.PR != nil {
-			pr = fmt.Sprintf("#%d", b.PR.Number)
+			entry.Number = b.PR.Number
+			entry.PRRef = fmt.Sprintf("#%d", b.PR.Number)
+			entry.State = b.PR.State
 			switch b.PR.State {
 			case "merged":
-				status = "✅ Merged"
+				entry.Status = "✅ Merged"
 			case "closed":
-				status = "❌ Closed"
+				entry.Status = "❌ Closed"
 			case "draft":
-				status = "📝 Draft"
+				entry.Status = "📝 Draft"
 			default:
-				status = "🔄 Open"
+				entry.Status = "🔄 Open"
 			}
 		}
 
-		if b.Name == currentBranch {
-			sb.WriteString(fmt.Sprintf("| **%s** | **`%s`** | **%s** | **🔄 This PR** |\n", num, branch, pr))
-		} else {
-			sb.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s |\n", num, branch, pr, status))
-		}
+		data.Branches = append(data.Branches, entry)
 	}
 
-	sb.WriteString("\n---\n")
-	sb.WriteString("*Managed by [stk](https://github.com/stefanaki/stk)*\n")
-
-	return sb.String()
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render stack section: %w", err)
+	}
+	return sb.String(), nil
 }
 
 // PRBranchInfo contains branch info for PR generation.
 type PRBranchInfo struct {
 	Name string
 	PR   *PR
+
+	// Fetched is set when PR was read from the provider, so its Body is
+	// the current description rather than empty.
+	Fetched bool
 }
@@ -8,6 +8,10 @@ import (
 // Manager provides high-level operations on stacks.
 type Manager struct {
 	storage *Storage
+
+	// AllowSharedBranches disables the check that prevents a branch from
+	// being added to more than one stack.
+	AllowSharedBranches bool
 }
 
 // NewManager creates a new stack manager.
@@ -72,12 +76,54 @@ func (m *Manager) Rename(oldName, newName string) error {
 	return m.storage.Rename(oldName, newName)
 }
 
+// StacksContaining returns the names of all stacks that contain the branch.
+func (m *Manager) StacksContaining(branchName string) ([]string, error) {
+	names, err := m.storage.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, name := range names {
+		s, err := m.storage.Load(name)
+		if err != nil {
+			continue
+		}
+		if s.HasBranch(branchName) {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+// checkNotShared returns an error if the branch already belongs to a stack
+// other than the given one, unless AllowSharedBranches is set.
+func (m *Manager) checkNotShared(stack *Stack, branchName string) error {
+	if m.AllowSharedBranches {
+		return nil
+	}
+
+	owners, err := m.StacksContaining(branchName)
+	if err != nil {
+		return nil // Can't check, proceed anyway
+	}
+	for _, owner := range owners {
+		if owner != stack.Name {
+			return fmt.Errorf("branch %q already belongs to stack %q", branchName, owner)
+		}
+	}
+	return nil
+}
+
 // AddBranch adds a branch to a stack after the specified branch.
 // If afterBranch is empty, adds at the end.
 func (m *Manager) AddBranch(stack *Stack, branchName, afterBranch string) error {
 	if stack.HasBranch(branchName) {
 		return fmt.Errorf("branch %q already in stack", branchName)
 	}
+	if err := m.checkNotShared(stack, branchName); err != nil {
+		return err
+	}
 
 	branch := NewBranch(branchName)
 
@@ -118,6 +164,9 @@ func (m *Manager) AppendBranch(stack *Stack, branchName string) error {
 	if stack.HasBranch(branchName) {
 		return fmt.Errorf("branch %q already in stack", branchName)
 	}
+	if err := m.checkNotShared(stack, branchName); err != nil {
+		return err
+	}
 
 	stack.Branches = append(stack.Branches, NewBranch(branchName))
 	stack.Updated = time.Now()
@@ -138,32 +187,155 @@ func (m *Manager) RemoveBranch(stack *Stack, branchName string) error {
 
 // MoveBranch moves a branch to a new position after the specified branch.
 func (m *Manager) MoveBranch(stack *Stack, branchName, afterBranch string) error {
-	idx := stack.FindBranch(branchName)
-	if idx < 0 {
-		return fmt.Errorf("branch %q not found in stack", branchName)
+	if afterBranch == branchName {
+		return fmt.Errorf("cannot move branch %q after itself", branchName)
 	}
+	return m.MoveRange(stack, branchName, branchName, afterBranch)
+}
 
-	// Remove the branch
-	branch := stack.Branches[idx]
-	stack.Branches = append(stack.Branches[:idx], stack.Branches[idx+1:]...)
+// MoveRange moves the contiguous block of branches from first to last
+// (inclusive) to a new position after the specified branch, preserving
+// their relative order.
+func (m *Manager) MoveRange(stack *Stack, first, last, afterBranch string) error {
+	start := stack.FindBranch(first)
+	if start < 0 {
+		return fmt.Errorf("branch %q not found in stack", first)
+	}
+	end := stack.FindBranch(last)
+	if end < 0 {
+		return fmt.Errorf("branch %q not found in stack", last)
+	}
+	if end < start {
+		return fmt.Errorf("branch %q comes before %q in the stack", last, first)
+	}
+
+	// Remove the block
+	block := make([]Branch, end-start+1)
+	copy(block, stack.Branches[start:end+1])
+	rest := make([]Branch, 0, len(stack.Branches)-len(block))
+	rest = append(rest, stack.Branches[:start]...)
+	rest = append(rest, stack.Branches[end+1:]...)
 
 	// Find new position
-	if afterBranch == "" || afterBranch == stack.Base {
-		// Insert at beginning
-		stack.Branches = append([]Branch{branch}, stack.Branches...)
-	} else {
-		newIdx := stack.FindBranch(afterBranch)
+	insertAt := 0
+	if afterBranch != "" && afterBranch != stack.Base {
+		for _, b := range block {
+			if b.Name == afterBranch {
+				return fmt.Errorf("cannot move branches after %q, which is being moved", afterBranch)
+			}
+		}
+		tmp := &Stack{Branches: rest}
+		newIdx := tmp.FindBranch(afterBranch)
 		if newIdx < 0 {
 			return fmt.Errorf("branch %q not found in stack", afterBranch)
 		}
-		// Insert after newIdx
-		newBranches := make([]Branch, 0, len(stack.Branches)+1)
-		newBranches = append(newBranches, stack.Branches[:newIdx+1]...)
-		newBranches = append(newBranches, branch)
-		newBranches = append(newBranches, stack.Branches[newIdx+1:]...)
-		stack.Branches = newBranches
+		insertAt = newIdx + 1
+	}
+	if insertAt == start {
+		if insertAt == 0 {
+			return fmt.Errorf("branch %q is already first in the stack", first)
+		}
+		return fmt.Errorf("branch %q is already after %q", first, afterBranch)
+	}
+
+	var order []string
+	for _, b := range rest[:insertAt] {
+		order = append(order, b.Name)
+	}
+	for _, b := range block {
+		order = append(order, b.Name)
+	}
+	for _, b := range rest[insertAt:] {
+		order = append(order, b.Name)
+	}
+	return m.ReorderBranches(stack, order)
+}
+
+// ReorderBranches puts the stack's branches in the given order, keeping
+// their metadata. The order must name every branch in the stack exactly
+// once; the stack is left unchanged otherwise.
+func (m *Manager) ReorderBranches(stack *Stack, order []string) error {
+	if len(order) != len(stack.Branches) {
+		return fmt.Errorf("new order has %d branch(es), stack has %d", len(order), len(stack.Branches))
 	}
 
+	newBranches := make([]Branch, 0, len(order))
+	seen := make(map[string]bool)
+	for _, name := range order {
+		if seen[name] {
+			return fmt.Errorf("branch %q appears more than once in the new order", name)
+		}
+		seen[name] = true
+
+		idx := stack.FindBranch(name)
+		if idx < 0 {
+			return fmt.Errorf("branch %q not found in stack", name)
+		}
+		newBranches = append(newBranches, stack.Branches[idx])
+	}
+	stack.Branches = newBranches
+
+	stack.Updated = time.Now()
+	return m.storage.Save(stack)
+}
+
+// SetBaseOverride sets (or, with an empty base, clears) the base override
+// of a branch.
+func (m *Manager) SetBaseOverride(stack *Stack, branchName, base string) error {
+	idx := stack.FindBranch(branchName)
+	if idx < 0 {
+		return fmt.Errorf("branch %q not found in stack", branchName)
+	}
+	if base == branchName {
+		return fmt.Errorf("branch %q cannot be its own base", branchName)
+	}
+
+	stack.Branches[idx].BaseOverride = base
+	stack.Updated = time.Now()
+	return m.storage.Save(stack)
+}
+
+// SetFrozen marks a branch as frozen (or thaws it).
+func (m *Manager) SetFrozen(stack *Stack, branchName string, frozen bool) error {
+	idx := stack.FindBranch(branchName)
+	if idx < 0 {
+		return fmt.Errorf("branch %q not found in stack", branchName)
+	}
+
+	stack.Branches[idx].Frozen = frozen
+	stack.Updated = time.Now()
+	return m.storage.Save(stack)
+}
+
+// SetDescription sets a branch's description. An empty text clears it.
+func (m *Manager) SetDescription(stack *Stack, branchName, text string) error {
+	idx := stack.FindBranch(branchName)
+	if idx < 0 {
+		return fmt.Errorf("branch %q not found in stack", branchName)
+	}
+
+	stack.Branches[idx].Description = text
+	stack.Updated = time.Now()
+	return m.storage.Save(stack)
+}
+
+// SetMergedParentTip records the tip of a branch's merged parent. An empty
+// sha clears it.
+func (m *Manager) SetMergedParentTip(stack *Stack, branchName, sha string) error {
+	idx := stack.FindBranch(branchName)
+	if idx < 0 {
+		return fmt.Errorf("branch %q not found in stack", branchName)
+	}
+
+	stack.Branches[idx].MergedParentTip = sha
+	stack.Updated = time.Now()
+	return m.storage.Save(stack)
+}
+
+// SetNoStackSection sets whether the stack's PR descriptions leave out the
+// stack section.
+func (m *Manager) SetNoStackSection(stack *Stack, disabled bool) error {
+	stack.NoStackSection = disabled
 	stack.Updated = time.Now()
 	return m.storage.Save(stack)
 }
@@ -196,19 +368,47 @@ func (m *Manager) TakeSnapshot(stack *Stack, getSHA func(string) (string, error)
 	return m.storage.Save(stack)
 }
 
+// SetSnapshotAutostash records the stash holding changes set aside for the
+// operation the snapshot guards. It does nothing without a snapshot.
+func (m *Manager) SetSnapshotAutostash(stack *Stack, sha string) error {
+	if stack.Snapshot == nil {
+		return nil
+	}
+	stack.Snapshot.Autostash = sha
+	return m.storage.Save(stack)
+}
+
 // ClearSnapshot removes the snapshot from a stack.
 func (m *Manager) ClearSnapshot(stack *Stack) error {
 	stack.Snapshot = nil
 	return m.storage.Save(stack)
 }
 
-// UpdatePR updates PR metadata for a branch.
+// SetEditProgress saves (or, with nil, clears) 'stk edit --all' progress.
+func (m *Manager) SetEditProgress(stack *Stack, progress *EditProgress) error {
+	stack.EditAll = progress
+	return m.storage.Save(stack)
+}
+
+// UpdatePR updates PR metadata for a branch. For the same PR, an empty
+// MergeSHA or Author keeps the recorded value.
 func (m *Manager) UpdatePR(stack *Stack, branchName string, pr *PR) error {
 	idx := stack.FindBranch(branchName)
 	if idx < 0 {
 		return fmt.Errorf("branch %q not found in stack", branchName)
 	}
 
+	// Refreshes rebuild the PR from provider data; keep what only stk
+	// records, or the provider may not report, for the same PR
+	if old := stack.Branches[idx].PR; pr != nil && old != nil && old.Number == pr.Number {
+		if pr.MergeSHA == "" {
+			pr.MergeSHA = old.MergeSHA
+		}
+		if pr.Author == "" {
+			pr.Author = old.Author
+		}
+	}
+
 	stack.Branches[idx].PR = pr
 	stack.Updated = time.Now()
 	return m.storage.Save(stack)
@@ -221,8 +421,9 @@ func (m *Manager) Validate(stack *Stack, branchExists func(string) bool) []Valid
 	// Check base exists
 	if !branchExists(stack.Base) {
 		errors = append(errors, ValidationError{
-			Branch:  stack.Base,
-			Message: "base branch does not exist",
+			Branch:   stack.Base,
+			Message:  "base branch does not exist",
+			Severity: SeverityError,
 		})
 	}
 
@@ -230,8 +431,20 @@ func (m *Manager) Validate(stack *Stack, branchExists func(string) bool) []Valid
 	for _, b := range stack.Branches {
 		if !branchExists(b.Name) {
 			errors = append(errors, ValidationError{
-				Branch:  b.Name,
-				Message: "branch does not exist",
+				Branch:   b.Name,
+				Message:  "branch does not exist",
+				Severity: SeverityError,
+			})
+		}
+	}
+
+	// Check base overrides exist
+	for _, b := range stack.Branches {
+		if b.BaseOverride != "" && !branchExists(b.BaseOverride) {
+			errors = append(errors, ValidationError{
+				Branch:   b.Name,
+				Message:  fmt.Sprintf("base override %q does not exist", b.BaseOverride),
+				Severity: SeverityError,
 			})
 		}
 	}
@@ -241,12 +454,37 @@ func (m *Manager) Validate(stack *Stack, branchExists func(string) bool) []Valid
 	for _, b := range stack.Branches {
 		if seen[b.Name] {
 			errors = append(errors, ValidationError{
-				Branch:  b.Name,
-				Message: "duplicate branch in stack",
+				Branch:   b.Name,
+				Message:  "duplicate branch in stack",
+				Severity: SeverityError,
 			})
 		}
 		seen[b.Name] = true
 	}
 
+	// Open PRs should target their parent; the cached base is from the
+	// last time stk talked to the provider
+	for _, b := range stack.Branches {
+		if b.PR == nil || b.PR.Base == "" || b.PR.State == "merged" || b.PR.State == "closed" {
+			continue
+		}
+		if want := stack.GetPRBase(b.Name); b.PR.Base != want {
+			errors = append(errors, ValidationError{
+				Branch:   b.Name,
+				Message:  fmt.Sprintf("PR #%d targets %s but its parent is %s; run 'stk submit' to retarget", b.PR.Number, b.PR.Base, want),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+
+	// Leftover snapshot means a rebase was interrupted
+	if stack.Snapshot != nil {
+		errors = append(errors, ValidationError{
+			Branch:   stack.Name,
+			Message:  "stale rollback snapshot from an interrupted rebase",
+			Severity: SeverityWarning,
+		})
+	}
+
 	return errors
 }
@@ -0,0 +1,52 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const prCacheFile = ".prcache.json"
+
+// CachedPR is remote PR state along with when it was fetched.
+type CachedPR struct {
+	PR        PR        `json:"pr"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// prCachePath returns the path to the PR cache file.
+func (s *Storage) prCachePath() string {
+	return filepath.Join(s.stacksPath(), prCacheFile)
+}
+
+// LoadPRCache reads the PR cache, keyed by PR number. A missing or
+// unreadable cache yields an empty one.
+func (s *Storage) LoadPRCache() map[int]CachedPR {
+	cache := make(map[int]CachedPR)
+	data, err := os.ReadFile(s.prCachePath())
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(map[int]CachedPR)
+	}
+	return cache
+}
+
+// SavePRCache writes the PR cache.
+func (s *Storage) SavePRCache(cache map[int]CachedPR) error {
+	if err := s.EnsureDir(); err != nil {
+		return fmt.Errorf("failed to create stacks directory: %w", err)
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PR cache: %w", err)
+	}
+	if err := os.WriteFile(s.prCachePath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write PR cache: %w", err)
+	}
+	return nil
+}
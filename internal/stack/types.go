@@ -12,13 +12,34 @@ type Stack struct {
 	Updated  time.Time `yaml:"updated"`
 	Branches []Branch  `yaml:"branches"`
 	Snapshot *Snapshot `yaml:"snapshot,omitempty"`
+
+	// EditAll tracks an in-progress 'stk edit --all'.
+	EditAll *EditProgress `yaml:"edit_all,omitempty"`
+
+	// NoStackSection leaves the stack section out of this stack's PR
+	// descriptions.
+	NoStackSection bool `yaml:"no_stack_section,omitempty"`
 }
 
 // Branch represents a single branch in the stack.
 type Branch struct {
 	Name     string `yaml:"name"`
 	Upstream string `yaml:"upstream,omitempty"`
-	PR       *PR    `yaml:"pr,omitempty"`
+	// BaseOverride, when set, is used as the branch's parent for rebasing
+	// and PR targeting instead of the previous branch in the stack.
+	BaseOverride string `yaml:"base_override,omitempty"`
+	PR           *PR    `yaml:"pr,omitempty"`
+	// Frozen branches are never rebased or pushed by stk, e.g. shared
+	// integration branches that must not be rewritten.
+	Frozen bool `yaml:"frozen,omitempty"`
+	// Description is a free-form note about what the branch is for. It is
+	// only shown by stk and never affects git.
+	Description string `yaml:"description,omitempty"`
+	// MergedParentTip is the tip of the branch's parent when the parent
+	// was merged and removed from the stack. The branch's own commits start
+	// after it, so the next rebase replays only those, even if the parent
+	// was squash-merged into a single new commit.
+	MergedParentTip string `yaml:"merged_parent_tip,omitempty"`
 }
 
 // PR represents pull request metadata for a branch.
@@ -27,12 +48,29 @@ type PR struct {
 	URL    string `yaml:"url"`
 	State  string `yaml:"state"` // open, closed, merged, draft
 	Title  string `yaml:"title,omitempty"`
+	Base   string `yaml:"base,omitempty"`   // target branch when last seen
+	Author string `yaml:"author,omitempty"` // login of the PR's author
+
+	// MergeSHA is the merge (or squash) commit, if merged with stk.
+	MergeSHA string `yaml:"merge_sha,omitempty"`
+}
+
+// EditProgress records how far 'stk edit --all' got, so it can resume after
+// a conflict or a paused interactive rebase.
+type EditProgress struct {
+	Remaining []string `yaml:"remaining"` // branches left to edit, current first
+	Total     int      `yaml:"total"`
+	Original  string   `yaml:"original,omitempty"` // branch to return to
 }
 
 // Snapshot stores branch SHAs for atomic rollback.
 type Snapshot struct {
 	TakenAt time.Time         `yaml:"taken_at"`
 	Refs    map[string]string `yaml:"refs"` // branch name -> SHA
+
+	// Autostash is the stash entry (by commit SHA) holding changes set
+	// aside by 'stk sync --autostash'; they're restored on rollback too.
+	Autostash string `yaml:"autostash,omitempty"`
 }
 
 // Node represents a branch in the computed dependency graph.
@@ -50,10 +88,22 @@ type Graph struct {
 	Order []string // topological order (base first, then branches)
 }
 
+// Severity levels for validation issues.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
 // ValidationError represents a stack validation issue.
 type ValidationError struct {
-	Branch  string
-	Message string
+	Branch   string
+	Message  string
+	Severity string // error or warning
+}
+
+// IsError reports whether the issue is a hard error rather than a warning.
+func (v ValidationError) IsError() bool {
+	return v.Severity != SeverityWarning
 }
 
 // NewStack creates a new stack with the given name and base branch.
@@ -92,15 +142,35 @@ func (s *Stack) HasBranch(name string) bool {
 }
 
 // GetParent returns the parent branch name for a given branch.
-// Returns the base branch if it's the first branch in the stack.
+// Returns the branch's base override if set, otherwise the previous branch,
+// or the base branch if it's the first branch in the stack.
 func (s *Stack) GetParent(name string) string {
 	idx := s.FindBranch(name)
+	if idx >= 0 && s.Branches[idx].BaseOverride != "" {
+		return s.Branches[idx].BaseOverride
+	}
 	if idx <= 0 {
 		return s.Base
 	}
 	return s.Branches[idx-1].Name
 }
 
+// GetPRBase returns the branch a PR for the given branch should target.
+// Parents whose PRs are already merged are skipped, since their changes
+// have landed in the branch below them.
+func (s *Stack) GetPRBase(name string) string {
+	idx := s.FindBranch(name)
+	if idx >= 0 && s.Branches[idx].BaseOverride != "" {
+		return s.Branches[idx].BaseOverride
+	}
+	for i := idx - 1; i >= 0; i-- {
+		if pr := s.Branches[i].PR; pr == nil || pr.State != "merged" {
+			return s.Branches[i].Name
+		}
+	}
+	return s.Base
+}
+
 // GetChildren returns all branches that depend on the given branch.
 func (s *Stack) GetChildren(name string) []string {
 	idx := s.FindBranch(name)
@@ -103,3 +103,46 @@ func PRBadge(number int, state string) string {
 	}
 	return color + fmt.Sprintf("#%d", number) + Reset
 }
+
+// ChecksBadge formats a CI status padded to width, colored by outcome.
+// Statuses with no CI configured render as a dimmed dash.
+func ChecksBadge(status string, width int) string {
+	text := status
+	color := Yellow
+	switch status {
+	case "passing":
+		text = IconCheck + " passing"
+		color = Green
+	case "failing":
+		text = IconCross + " failing"
+		color = Red
+	case "pending":
+		text = "… pending"
+	case "none":
+		text = "—"
+		color = Dim
+	}
+	return color + fmt.Sprintf("%-*s", width, text) + Reset
+}
+
+// MergeableBadge formats a PR's mergeability padded to width: "yes",
+// "conflicts", "computing", "none", or another reason it can't merge.
+func MergeableBadge(status string, width int) string {
+	text := status
+	color := Yellow
+	switch status {
+	case "yes":
+		text = IconCheck + " yes"
+		color = Green
+	case "conflicts":
+		text = "⚠ conflicts"
+		color = Red
+	case "computing":
+		text = "… computing"
+		color = Dim
+	case "none":
+		text = "—"
+		color = Dim
+	}
+	return color + fmt.Sprintf("%-*s", width, text) + Reset
+}
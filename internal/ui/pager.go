@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// PagerEnv is the environment variable that picks the pager, taking
+// precedence over PAGER.
+const PagerEnv = "STK_PAGER"
+
+// DefaultPager is used when neither STK_PAGER nor PAGER is set.
+const DefaultPager = "less -R"
+
+// Page writes content to stdout, through a pager if stdout is a terminal
+// and content is taller than it. An empty pager setting or "cat" disables
+// paging, and content is printed directly if the pager can't be started.
+func Page(content string) {
+	if !IsTerminal(os.Stdout) {
+		fmt.Print(content)
+		return
+	}
+	rows, _ := TerminalSize()
+	if rows == 0 || strings.Count(content, "\n") < rows {
+		fmt.Print(content)
+		return
+	}
+
+	pager, ok := os.LookupEnv(PagerEnv)
+	if !ok {
+		pager, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		pager = DefaultPager
+	}
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		fmt.Print(content)
+		return
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		// Only fall back if the pager never got to show anything
+		if _, isExit := err.(*exec.ExitError); !isExit {
+			fmt.Print(content)
+		}
+	}
+}
+
+// IsTerminal reports whether f is a terminal.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// TerminalSize returns the rows and columns of the controlling terminal.
+// LINES and COLUMNS take precedence; unknown dimensions are 0.
+func TerminalSize() (rows, cols int) {
+	rows = envInt("LINES")
+	cols = envInt("COLUMNS")
+	if rows > 0 && cols > 0 {
+		return rows, cols
+	}
+
+	cmd := exec.Command("stty", "size")
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	if err != nil {
+		return rows, cols
+	}
+	var sttyRows, sttyCols int
+	if _, err := fmt.Sscanf(string(out), "%d %d", &sttyRows, &sttyCols); err != nil {
+		return rows, cols
+	}
+	if rows == 0 {
+		rows = sttyRows
+	}
+	if cols == 0 {
+		cols = sttyCols
+	}
+	return rows, cols
+}
+
+// envInt returns a positive integer environment variable, or 0.
+func envInt(name string) int {
+	n, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -2,7 +2,9 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/stefanaki/stk/internal/stack"
 )
@@ -15,6 +17,17 @@ type TreeOptions struct {
 	CurrentBranch string
 	GetSHA        func(string) string
 	GetCommits    func(base, head string) int
+	// IsMoved reports whether a branch tip differs from the stack snapshot.
+	IsMoved func(string) bool
+	// BehindParent returns how many commits of its parent a branch lacks.
+	BehindParent func(string) int
+	// BranchPrefix is the configured prefix for new branches, if any.
+	BranchPrefix string
+	// ShowPRTitle adds each branch's recorded PR title after its badge.
+	ShowPRTitle bool
+	// Width is the terminal width PR titles are truncated to fit; 0 means
+	// no limit.
+	Width int
 }
 
 // RenderTree renders a stack as a tree.
@@ -36,14 +49,67 @@ func RenderTree(s *stack.Stack, opts TreeOptions) string {
 		// Add PR info if available
 		if opts.ShowPR && branch.PR != nil {
 			line += " " + PRBadge(branch.PR.Number, branch.PR.State)
+			// The dimmed badge alone is easy to miss
+			if branch.PR.State == "draft" {
+				line += " " + Yellow + "[draft]" + Reset
+			}
 		}
 
-		sb.WriteString(line + "\n")
+		var suffix string
+		if opts.BehindParent != nil {
+			if n := opts.BehindParent(branch.Name); n > 0 {
+				suffix += " " + Red + fmt.Sprintf("needs restack (%d behind parent)", n) + Reset
+			}
+		}
+
+		if branch.Description != "" {
+			suffix += " " + Dim + "— " + branch.Description + Reset
+		}
+
+		// The title fills whatever room the rest of the line leaves
+		if opts.ShowPRTitle && branch.PR != nil && branch.PR.Title != "" {
+			title := branch.PR.Title
+			if opts.Width > 0 {
+				// Too little room to show anything useful drops the title
+				room := opts.Width - visibleLen(line) - visibleLen(suffix) - 1
+				if room < minTitleWidth && utf8.RuneCountInString(title) > room {
+					title = ""
+				} else {
+					title = Truncate(title, room)
+				}
+			}
+			if title != "" {
+				line += " " + Dim + title + Reset
+			}
+		}
+
+		sb.WriteString(line + suffix + "\n")
 	}
 
 	return sb.String()
 }
 
+// ansiPattern matches the color escape codes used in this package.
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// visibleLen returns the number of characters s takes up on screen.
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(s, ""))
+}
+
+// minTitleWidth is the fewest characters of a PR title worth showing.
+const minTitleWidth = 8
+
+// Truncate shortens s to at most width characters, marking the cut with an
+// ellipsis. A width of 0 means no limit.
+func Truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 func renderBranchLine(name string, depth int, isLast bool, opts TreeOptions) string {
 	var sb strings.Builder
 
@@ -78,6 +144,11 @@ func renderBranchLine(name string, depth int, isLast bool, opts TreeOptions) str
 		}
 	}
 
+	// Snapshot drift
+	if opts.IsMoved != nil && opts.IsMoved(name) {
+		sb.WriteString(" " + Yellow + "(moved since snapshot)" + Reset)
+	}
+
 	// Commit count
 	if opts.ShowCommits && opts.GetCommits != nil && depth > 0 {
 		// This would need the parent branch name passed in
@@ -97,9 +168,117 @@ func RenderStatus(s *stack.Stack, opts TreeOptions) string {
 	// Show additional info
 	sb.WriteString(Dim + fmt.Sprintf("Base: %s", s.Base) + Reset + "\n")
 	sb.WriteString(Dim + fmt.Sprintf("Branches: %d", len(s.Branches)) + Reset + "\n")
+	if opts.BranchPrefix != "" {
+		sb.WriteString(Dim + fmt.Sprintf("Branch prefix: %s", opts.BranchPrefix) + Reset + "\n")
+	}
 
 	if s.Snapshot != nil {
 		sb.WriteString(Dim + fmt.Sprintf("Snapshot: %s", s.Snapshot.TakenAt.Format("2006-01-02 15:04:05")) + Reset + "\n")
+
+		if opts.IsMoved != nil {
+			moved := 0
+			for _, name := range s.AllBranches() {
+				if opts.IsMoved(name) {
+					moved++
+				}
+			}
+			if moved > 0 {
+				sb.WriteString(Yellow + fmt.Sprintf("%s %d branch(es) moved since the snapshot; a rebase may be in progress or was interrupted", IconWarning, moved) + Reset + "\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// RenderShort renders a stack compactly, one line per branch:
+// position, a "*" marking the current branch, name, PR number and state.
+// Branches without a PR show "-" for both.
+func RenderShort(s *stack.Stack, current string) string {
+	var sb strings.Builder
+
+	width := 0
+	for _, branch := range s.Branches {
+		if n := utf8.RuneCountInString(branch.Name); n > width {
+			width = n
+		}
+	}
+
+	for i, branch := range s.Branches {
+		isCurrent := branch.Name == current
+		marker := " "
+		if isCurrent {
+			marker = "*"
+		}
+		number, state := Dim+"-"+Reset, Dim+"-"+Reset
+		if branch.PR != nil && branch.PR.Number > 0 {
+			number = PRBadge(branch.PR.Number, branch.PR.State)
+			state = branch.PR.State
+		}
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(branch.Name))
+		fmt.Fprintf(&sb, "%d %s %s%s %s %s\n", i+1, marker, BranchName(branch.Name, isCurrent), padding, number, state)
+	}
+
+	return sb.String()
+}
+
+// RenderDOT renders the stack's dependency graph in Graphviz DOT format.
+// Branch nodes are labeled with their PR number and state, and the current
+// branch is drawn bold.
+func RenderDOT(s *stack.Stack, current string) string {
+	var sb strings.Builder
+	g := s.BuildGraph()
+
+	fmt.Fprintf(&sb, "digraph %q {\n", s.Name)
+	sb.WriteString("  rankdir=BT;\n")
+	sb.WriteString("  node [shape=box, fontname=\"monospace\"];\n")
+
+	for _, name := range g.Order {
+		node := g.Nodes[name]
+		label := name
+		var attrs []string
+		if name == g.Base {
+			label += "\n(base)"
+			attrs = append(attrs, "style=filled", `fillcolor="#eeeeee"`)
+		} else if pr := node.Branch.PR; pr != nil && pr.Number > 0 {
+			label += fmt.Sprintf("\n#%d %s", pr.Number, pr.State)
+		}
+		if name == current {
+			attrs = append(attrs, "penwidth=2")
+		}
+		attrs = append([]string{"label=" + fmt.Sprintf("%q", label)}, attrs...)
+		fmt.Fprintf(&sb, "  %q [%s];\n", name, strings.Join(attrs, ", "))
+	}
+
+	for _, name := range g.Order {
+		if parent := g.Nodes[name].Parent; parent != nil {
+			fmt.Fprintf(&sb, "  %q -> %q;\n", name, parent.Branch.Name)
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// RenderLegend renders a key to the icons and colors used by RenderTree
+// and PRBadge.
+func RenderLegend() string {
+	var sb strings.Builder
+
+	sb.WriteString(Bold + "Legend" + Reset + "\n")
+	entries := []struct{ glyph, meaning string }{
+		{IconDot, "current branch, name shown in green"},
+		{IconCircle, "other branch; the first line is the base"},
+		{IconBranch + "/" + IconBranchL, "child of the line above"},
+		{PRBadge(1, "open"), "open PR"},
+		{PRBadge(1, "draft") + " " + Yellow + "[draft]" + Reset, "draft PR"},
+		{PRBadge(1, "merged"), "merged PR"},
+		{PRBadge(1, "closed"), "closed PR"},
+		{Yellow + "(moved since snapshot)" + Reset, "tip differs from the rollback snapshot"},
+		{Red + "needs restack" + Reset, "missing commits from its parent; run 'stk sync --no-fetch'"},
+	}
+	for _, e := range entries {
+		sb.WriteString("  " + e.glyph + "  " + Dim + e.meaning + Reset + "\n")
 	}
 
 	return sb.String()